@@ -0,0 +1,150 @@
+// Copyright © The Nune Author. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package nune
+
+// writable makes sure the Tensor can be written to in place,
+// reporting the failed operation otherwise.
+func (t Tensor[T]) writable(op string) (Tensor[T], bool) {
+	err := verifyWritable(t.shape, t.stride)
+	if err != nil {
+		err = newOpError(op, err, -1, t.shape)
+		if t.env().Interactive {
+			panic(err)
+		} else {
+			t.Err = err
+			return t, false
+		}
+	}
+
+	return t, true
+}
+
+// position returns the position in the data buffer of the element
+// at the given indices, which must hold one index per axis.
+func (t Tensor[T]) position(op string, indices []int) (int, error) {
+	if len(indices) != len(t.shape) {
+		return 0, newOpError(op, ErrArgsBounds, -1, t.shape)
+	}
+
+	pos := t.offset
+	for i, idx := range indices {
+		err := verifyAxisBounds(idx, t.shape[i]-1)
+		if err != nil {
+			return 0, newOpError(op, err, i, t.shape)
+		}
+
+		pos += idx * t.stride[i]
+	}
+
+	return pos, nil
+}
+
+// At returns the element at the given indices of the Tensor,
+// which must hold one index per axis.
+// Panics if the Tensor holds an error, or if the indices are out
+// of bounds, since there's no Tensor to carry the error.
+func (t Tensor[T]) At(indices ...int) T {
+	if t.Err != nil {
+		panic(t.Err)
+	}
+
+	pos, err := t.position("At", indices)
+	if err != nil {
+		panic(err)
+	}
+
+	return t.data[pos]
+}
+
+// Set sets the element at the given indices of the Tensor,
+// which must hold one index per axis, to the given value.
+// The element is set in place, so that all the views
+// sharing the Tensor's data buffer see the change.
+func (t Tensor[T]) Set(x T, indices ...int) Tensor[T] {
+	if t.Err != nil {
+		if t.env().Interactive {
+			panic(t.Err)
+		} else {
+			return t
+		}
+	}
+
+	if t, ok := t.writable("Set"); !ok {
+		return t
+	}
+
+	pos, err := t.position("Set", indices)
+	if err != nil {
+		if t.env().Interactive {
+			panic(err)
+		} else {
+			t.Err = err
+			return t
+		}
+	}
+
+	t.data[pos] = x
+
+	return t
+}
+
+// Assign copies the given source - be it a numeric type, a sequence,
+// nested sequences or a Tensor - broadcast to the Tensor's shape,
+// into the Tensor's elements in place, so that a view such as the
+// one returned by Index or Slice updates a region of its base Tensor.
+func (t Tensor[T]) Assign(src any) Tensor[T] {
+	if t.Err != nil {
+		if t.env().Interactive {
+			panic(t.Err)
+		} else {
+			return t
+		}
+	}
+
+	if t, ok := t.writable("Assign"); !ok {
+		return t
+	}
+
+	s := asTensor[T](src)
+	if s.Err != nil {
+		err := newOpError("Assign", s.Err, -1, t.shape)
+		if t.env().Interactive {
+			panic(err)
+		} else {
+			t.Err = err
+			return t
+		}
+	}
+
+	if !s.Broadable(t.shape...) {
+		err := newOpError("Assign", ErrStorageDump, -1, t.shape, s.shape)
+		if t.env().Interactive {
+			panic(err)
+		} else {
+			t.Err = err
+			return t
+		}
+	}
+
+	// the source might overlap the Tensor if it shares its data buffer
+	if &s.data[0] == &t.data[0] {
+		s = s.Clone()
+	}
+
+	s = s.Expand(t.shape...)
+
+	offsets := []int{t.offset, s.offset}
+	strides := [][]int{t.stride, s.stride}
+
+	handleViews(t.shape, offsets, strides, t.env().configCPU(t.Numel()), func(_ int, pos, step []int, l int) {
+		for j := 0; j < l; j++ {
+			t.data[pos[0]] = s.data[pos[1]]
+			pos[0] += step[0]
+			pos[1] += step[1]
+		}
+	})
+
+	return t
+}
@@ -0,0 +1,36 @@
+// Copyright © The Nune Author. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package nune_test
+
+import (
+	"testing"
+
+	"github.com/vorduin/nune"
+)
+
+func BenchmarkAt1e6(b *testing.B) {
+	tensor := nune.Range[float64](0, 1e6, 1).Reshape(100, 200, 50)
+
+	benchmarkMicro(b, func() {
+		tensor.At(42, 137, 7)
+	})
+}
+
+func BenchmarkSet1e6(b *testing.B) {
+	tensor := nune.Range[float64](0, 1e6, 1).Reshape(100, 200, 50)
+
+	benchmarkMicro(b, func() {
+		tensor.Set(1, 42, 137, 7)
+	})
+}
+
+func BenchmarkAssign(b *testing.B) {
+	tensor := newTensor().Reshape(1e4, 1e3)
+	row := nune.Range[float64](0, 1e3, 1)
+
+	benchmarkOp(b, func() {
+		tensor.Assign(row)
+	})
+}
@@ -68,6 +68,10 @@ func (t Tensor[T]) Size(axis int) int {
 // Broadable returns whether or not the Tensor can be
 // broadcast to the given shape.
 func (t *Tensor[T]) Broadable(shape ...int) bool {
+	if len(shape) == 0 {
+		return len(t.shape) == 0
+	}
+
 	err := verifyGoodShape(shape...)
 	if err != nil {
 		return false
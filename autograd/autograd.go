@@ -0,0 +1,284 @@
+// Copyright © The Nune Author. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package autograd implements reverse-mode automatic
+// differentiation over floating point Nune Tensors.
+//
+// Variables are created on a Tape, which records every operation
+// performed on them along with the way to propagate gradients through
+// it. Calling Backward on a Variable then walks the Tape backwards
+// and accumulates the gradients of the Variable with respect to
+// every Variable it was computed from.
+//
+// Operations never modify their operands' Tensors, unlike the
+// in-place pointwise operations of Nune's Tensors.
+package autograd
+
+import (
+	"github.com/vorduin/nune"
+	"github.com/vorduin/slices"
+)
+
+// Float is the set of all floating point types and their supersets.
+type Float interface {
+	~float32 | ~float64
+}
+
+// A Tape records the operations performed on its Variables.
+// A Tape is not safe for concurrent use.
+type Tape[T Float] struct {
+	vars []*Variable[T] // the Tape's Variables, in the order of their creation
+}
+
+// NewTape returns a new, empty Tape.
+func NewTape[T Float]() *Tape[T] {
+	return &Tape[T]{}
+}
+
+// A Variable is a node of a Tape's computation graph, holding a Tensor
+// computed from other Variables, or given as an input of the graph.
+type Variable[T Float] struct {
+	value        nune.Tensor[T]  // the Variable's value
+	grad         nune.Tensor[T]  // the gradient accumulated in an input Variable
+	hasGrad      bool            // whether or not a gradient was accumulated
+	requiresGrad bool            // whether or not gradients flow to the Variable
+	input        bool            // whether or not the Variable was created by Var
+	tape         *Tape[T]        // the Tape the Variable is recorded on
+	index        int             // the Variable's index on its Tape
+	parents      []*Variable[T]  // the Variables the Variable is computed from
+	backward     backwardFunc[T] // propagates the Variable's gradient to its parents
+}
+
+// A backwardFunc returns the gradients of an operation's result with
+// respect to each of its operands, given the gradient g of the result.
+type backwardFunc[T Float] func(g nune.Tensor[T]) []nune.Tensor[T]
+
+// push records a Variable on the Tape.
+func (tp *Tape[T]) push(v *Variable[T]) *Variable[T] {
+	v.tape = tp
+	v.index = len(tp.vars)
+	tp.vars = append(tp.vars, v)
+
+	return v
+}
+
+// Var returns a new input Variable holding the given Tensor,
+// with respect to which gradients are computed.
+func (tp *Tape[T]) Var(t nune.Tensor[T]) *Variable[T] {
+	return tp.push(&Variable[T]{
+		value:        t,
+		requiresGrad: true,
+		input:        true,
+	})
+}
+
+// Const returns a new Variable holding the given Tensor,
+// with respect to which no gradient is computed.
+func (tp *Tape[T]) Const(t nune.Tensor[T]) *Variable[T] {
+	return tp.push(&Variable[T]{
+		value: t,
+	})
+}
+
+// Reset forgets all the operations recorded on the Tape
+// and clears the gradients of its input Variables,
+// which can then be reused to record new operations.
+// Every other Variable, including the constants, is forgotten
+// and must not be used anymore.
+func (tp *Tape[T]) Reset() {
+	inputs := tp.vars[:0]
+	for _, v := range tp.vars {
+		if v.input {
+			v.grad, v.hasGrad = nune.Tensor[T]{}, false
+			v.index = len(inputs)
+			inputs = append(inputs, v)
+		}
+	}
+
+	// drop the references to the forgotten Variables
+	for i := len(inputs); i < len(tp.vars); i++ {
+		tp.vars[i] = nil
+	}
+	tp.vars = inputs
+}
+
+// ZeroGrad clears the gradients accumulated in the Tape's input Variables.
+func (tp *Tape[T]) ZeroGrad() {
+	for _, v := range tp.vars {
+		v.grad, v.hasGrad = nune.Tensor[T]{}, false
+	}
+}
+
+// record records the result of an operation on the given operands,
+// whose gradients are computed by the given function.
+func (tp *Tape[T]) record(value nune.Tensor[T], parents []*Variable[T], backward backwardFunc[T]) *Variable[T] {
+	v := &Variable[T]{
+		value: value,
+	}
+
+	for _, p := range parents {
+		v.requiresGrad = v.requiresGrad || p.requiresGrad
+	}
+
+	// the operations no gradient flows through
+	// are recorded as constants
+	if v.requiresGrad && value.Err == nil {
+		v.parents = parents
+		v.backward = backward
+	}
+
+	return tp.push(v)
+}
+
+// Value returns the Tensor held by the Variable.
+func (v *Variable[T]) Value() nune.Tensor[T] {
+	return v.value
+}
+
+// Err returns the error held by the Variable's Tensor, if any.
+func (v *Variable[T]) Err() error {
+	return v.value.Err
+}
+
+// Tape returns the Tape the Variable is recorded on.
+func (v *Variable[T]) Tape() *Tape[T] {
+	return v.tape
+}
+
+// RequiresGrad returns whether or not gradients
+// are computed with respect to the Variable.
+func (v *Variable[T]) RequiresGrad() bool {
+	return v.requiresGrad
+}
+
+// Grad returns the gradient accumulated in the input Variable
+// by the calls to Backward, or zeros if there is none.
+func (v *Variable[T]) Grad() nune.Tensor[T] {
+	if !v.hasGrad {
+		return nune.ZerosLike[T](v.value)
+	}
+
+	return v.grad
+}
+
+// Backward computes the gradients of the Variable with respect to
+// the input Variables it was computed from, and accumulates them in
+// these Variables. The Variable's own gradient is taken to be ones,
+// so that a Variable that isn't a scalar gets the gradients of the
+// sum of its elements. It returns the first error encountered,
+// or panics if the environment is interactive.
+func (v *Variable[T]) Backward() error {
+	if v.value.Err != nil {
+		return fail(v.value, v.value.Err)
+	}
+
+	if !v.requiresGrad {
+		return nil
+	}
+
+	grads := make([]nune.Tensor[T], v.index+1)
+	hasGrad := make([]bool, v.index+1)
+
+	grads[v.index], hasGrad[v.index] = nune.OnesLike[T](v.value), true
+
+	for i := v.index; i >= 0; i-- {
+		u := v.tape.vars[i]
+		if !hasGrad[i] {
+			continue
+		}
+
+		g := grads[i]
+		if g.Err != nil {
+			return fail(v.value, g.Err)
+		}
+
+		// an input Variable accumulates its gradient
+		if u.parents == nil {
+			if u.requiresGrad {
+				if u.hasGrad {
+					u.grad = u.grad.Add(g)
+				} else {
+					u.grad, u.hasGrad = g.Clone(), true
+				}
+			}
+			continue
+		}
+
+		for k, pg := range u.backward(g) {
+			p := u.parents[k]
+			if !p.requiresGrad {
+				continue
+			}
+
+			// the propagated gradients might be shared,
+			// so they're copied before being accumulated
+			if hasGrad[p.index] {
+				grads[p.index] = grads[p.index].Add(pg)
+			} else {
+				grads[p.index], hasGrad[p.index] = pg.Clone(), true
+			}
+		}
+
+		// the intermediate gradients aren't needed anymore
+		grads[i] = nune.Tensor[T]{}
+	}
+
+	return nil
+}
+
+// fail returns the given error, or panics if
+// the Tensor's environment is interactive.
+func fail[T Float](t nune.Tensor[T], err error) error {
+	interactive := nune.EnvConfig.Interactive
+	if e := t.Engine(); e != nil {
+		interactive = e.Interactive
+	}
+
+	if interactive {
+		panic(err)
+	}
+
+	return err
+}
+
+// operand returns the given operand as a Variable, where values
+// other than Variables are recorded on the Tape as constants.
+func (v *Variable[T]) operand(other any) *Variable[T] {
+	switch other := other.(type) {
+	case *Variable[T]:
+		return other
+	case nune.Tensor[T]:
+		return v.tape.Const(other)
+	default:
+		return v.tape.Const(nune.From[T](other).WithEngine(v.value.Engine()))
+	}
+}
+
+// unbroadcast reduces a gradient to the given shape by summing it
+// over the axes its operand was broadcast along in the operation.
+func unbroadcast[T Float](g nune.Tensor[T], shape []int) nune.Tensor[T] {
+	for g.Err == nil && g.Rank() > len(shape) {
+		g = g.SumAxis(0, false)
+	}
+
+	for i := 0; g.Err == nil && i < len(shape); i++ {
+		if shape[i] == 1 && g.Size(i) != 1 {
+			g = g.SumAxis(i, true)
+		}
+	}
+
+	return g
+}
+
+// swapLast returns the axes of a Tensor of the given rank
+// with its last two axes swapped.
+func swapLast(rank int) []int {
+	axes := slices.WithLen[int](rank)
+	for i := range axes {
+		axes[i] = i
+	}
+	axes[rank-2], axes[rank-1] = axes[rank-1], axes[rank-2]
+
+	return axes
+}
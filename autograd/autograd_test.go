@@ -0,0 +1,109 @@
+// Copyright © The Nune Author. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package autograd_test
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/vorduin/nune"
+	"github.com/vorduin/nune/autograd"
+)
+
+func benchmarkMilli(b *testing.B, f func()) {
+	b.ResetTimer()
+
+	start := time.Now()
+	for i := 0; i < b.N; i++ {
+		f()
+	}
+	execTime := time.Since(start)
+
+	b.ReportMetric(0, "ns/op")
+	b.ReportMetric((1e3*execTime.Seconds())/float64(b.N), "ms/op")
+}
+
+// newTensor returns a Tensor of the given shape
+// filled with values in the interval (0, 1).
+func newTensor(shape ...int) nune.Tensor[float64] {
+	n := 1
+	for _, s := range shape {
+		n *= s
+	}
+
+	return nune.Range[float64](0, n, 1).Map(func(x float64) float64 {
+		return math.Abs(math.Sin(x))
+	}).Reshape(shape...)
+}
+
+func BenchmarkPointwise(b *testing.B) {
+	x := newTensor(1000, 1000)
+
+	benchmarkMilli(b, func() {
+		tape := autograd.NewTape[float64]()
+		v := tape.Var(x)
+		v.Mul(v).Exp().Add(1).Log().Sum().Backward()
+	})
+}
+
+func BenchmarkLinear(b *testing.B) {
+	x := newTensor(256, 512)
+	w := newTensor(512, 256)
+	bias := newTensor(256)
+
+	benchmarkMilli(b, func() {
+		tape := autograd.NewTape[float64]()
+		vw, vb := tape.Var(w), tape.Var(bias)
+		tape.Const(x).MatMul(vw).Add(vb).Tanh().Mean().Backward()
+	})
+}
+
+func TestResetKeepsInputs(t *testing.T) {
+	tape := autograd.NewTape[float64]()
+	w := tape.Var(newTensor(4, 4))
+	ones := nune.OnesLike[float64](w.Value())
+
+	var n int
+	for i := 0; i < 5; i++ {
+		err := w.Mul(ones).Add(2.0).Sum().Backward()
+		if err != nil {
+			t.Fatal(err)
+		}
+		tape.Reset()
+
+		if i == 0 {
+			n = tape.Len()
+		} else if tape.Len() != n {
+			t.Fatalf("Reset: the Tape grew from %d to %d Variables", n, tape.Len())
+		}
+	}
+
+	if n != 1 {
+		t.Fatalf("Reset: got %d Variables, want 1", n)
+	}
+}
+
+func TestDigamma(t *testing.T) {
+	cases := []struct {
+		x, want float64
+	}{
+		{1, -0.5772156649015329},
+		{0.5, -1.9635100260214235},
+		{-0.5, 0.03648997397857652},
+		{10, 2.251752589066721},
+		{3.7, 1.1671535393615111},
+	}
+
+	for _, c := range cases {
+		if got := autograd.Digamma(c.x); math.Abs(got-c.want) > 1e-12 {
+			t.Errorf("digamma(%v): got %v, want %v", c.x, got, c.want)
+		}
+	}
+
+	if !math.IsNaN(autograd.Digamma(-2)) {
+		t.Errorf("digamma(-2): got %v, want NaN", autograd.Digamma(-2))
+	}
+}
@@ -0,0 +1,13 @@
+// Copyright © The Nune Author. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package autograd
+
+// Len returns the number of Variables recorded on the Tape.
+func (tp *Tape[T]) Len() int {
+	return len(tp.vars)
+}
+
+// Digamma exposes digamma to the tests.
+var Digamma = digamma
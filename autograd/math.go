@@ -0,0 +1,462 @@
+// Copyright © The Nune Author. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package autograd
+
+import (
+	"math"
+
+	"github.com/vorduin/nune"
+)
+
+// digamma returns the logarithmic derivative of the Gamma function at x,
+// or a NaN at the poles of the Gamma function.
+func digamma(x float64) float64 {
+	if x <= 0 && x == math.Floor(x) {
+		return math.NaN()
+	}
+
+	// the reflection formula moves x to the positive half-line
+	if x < 0.5 {
+		return digamma(1-x) - math.Pi/math.Tan(math.Pi*x)
+	}
+
+	// the recurrence moves x to where the asymptotic series converges
+	var psi float64
+	for ; x < 10; x++ {
+		psi -= 1 / x
+	}
+
+	x2 := 1 / (x * x)
+	psi += math.Log(x) - 0.5/x - x2*(1.0/12-x2*(1.0/120-x2*(1.0/252-x2*(1.0/240-x2/132))))
+
+	return psi
+}
+
+// pointwise records a pointwise operation on the Variable, computed in
+// place by f in a copy of the Variable's value, whose derivative df is
+// computed from each element x of the Variable and its result y.
+func (v *Variable[T]) pointwise(f func(nune.Tensor[T]) nune.Tensor[T], df func(x, y float64) float64) *Variable[T] {
+	out := f(v.value.Clone())
+
+	return v.unary(out, func(g nune.Tensor[T]) nune.Tensor[T] {
+		d := v.value.Clone().Zip(out, func(x, y T) T {
+			return T(df(float64(x), float64(y)))
+		})
+
+		return d.Mul(g)
+	})
+}
+
+// constant records a piecewise constant operation on the Variable,
+// whose gradient is zero wherever it's defined.
+func (v *Variable[T]) constant(f func(nune.Tensor[T]) nune.Tensor[T]) *Variable[T] {
+	return v.pointwise(f, func(x, y float64) float64 {
+		return 0
+	})
+}
+
+// Abs records the absolute value of each element in the Variable.
+func (v *Variable[T]) Abs() *Variable[T] {
+	return v.pointwise(nune.Tensor[T].Abs, func(x, y float64) float64 {
+		switch {
+		case x > 0:
+			return 1
+		case x < 0:
+			return -1
+		default:
+			return 0
+		}
+	})
+}
+
+// Acos records the arccosine of each element in the Variable.
+func (v *Variable[T]) Acos() *Variable[T] {
+	return v.pointwise(nune.Tensor[T].Acos, func(x, y float64) float64 {
+		return -1 / math.Sqrt(1-x*x)
+	})
+}
+
+// Acosh records the inverse hyperbolic cosine of each element in the Variable.
+func (v *Variable[T]) Acosh() *Variable[T] {
+	return v.pointwise(nune.Tensor[T].Acosh, func(x, y float64) float64 {
+		return 1 / math.Sqrt(x*x-1)
+	})
+}
+
+// Asin records the arcsine of each element in the Variable.
+func (v *Variable[T]) Asin() *Variable[T] {
+	return v.pointwise(nune.Tensor[T].Asin, func(x, y float64) float64 {
+		return 1 / math.Sqrt(1-x*x)
+	})
+}
+
+// Asinh records the inverse hyperbolic sine of each element in the Variable.
+func (v *Variable[T]) Asinh() *Variable[T] {
+	return v.pointwise(nune.Tensor[T].Asinh, func(x, y float64) float64 {
+		return 1 / math.Sqrt(x*x+1)
+	})
+}
+
+// Atan records the arctangent of each element in the Variable.
+func (v *Variable[T]) Atan() *Variable[T] {
+	return v.pointwise(nune.Tensor[T].Atan, func(x, y float64) float64 {
+		return 1 / (1 + x*x)
+	})
+}
+
+// Atan2 records the arc tangent of y/x, where x is each element in the Variable.
+func (v *Variable[T]) Atan2(y float64) *Variable[T] {
+	return v.pointwise(func(t nune.Tensor[T]) nune.Tensor[T] {
+		return t.Atan2(y)
+	}, func(x, _ float64) float64 {
+		return -y / (x*x + y*y)
+	})
+}
+
+// Atanh records the inverse hyperbolic tangent of each element in the Variable.
+func (v *Variable[T]) Atanh() *Variable[T] {
+	return v.pointwise(nune.Tensor[T].Atanh, func(x, y float64) float64 {
+		return 1 / (1 - x*x)
+	})
+}
+
+// Cbrt records the cubic root of each element in the Variable.
+func (v *Variable[T]) Cbrt() *Variable[T] {
+	return v.pointwise(nune.Tensor[T].Cbrt, func(x, y float64) float64 {
+		return 1 / (3 * y * y)
+	})
+}
+
+// Ceil records the least integer value greater than or equal to
+// each element in the Variable, whose gradient is zero.
+func (v *Variable[T]) Ceil() *Variable[T] {
+	return v.constant(nune.Tensor[T].Ceil)
+}
+
+// Copysign records a value with the magnitude of x and the sign of y,
+// where x is each element in the Variable.
+func (v *Variable[T]) Copysign(y float64) *Variable[T] {
+	return v.pointwise(func(t nune.Tensor[T]) nune.Tensor[T] {
+		return t.Copysign(y)
+	}, func(x, _ float64) float64 {
+		if math.Signbit(x) == math.Signbit(y) {
+			return 1
+		}
+		return -1
+	})
+}
+
+// Cos records the cosine of each radian element of the Variable.
+func (v *Variable[T]) Cos() *Variable[T] {
+	return v.pointwise(nune.Tensor[T].Cos, func(x, y float64) float64 {
+		return -math.Sin(x)
+	})
+}
+
+// Cosh records the hyperbolic cosine of each element in the Variable.
+func (v *Variable[T]) Cosh() *Variable[T] {
+	return v.pointwise(nune.Tensor[T].Cosh, func(x, y float64) float64 {
+		return math.Sinh(x)
+	})
+}
+
+// Dim records the maximum of x-y or 0, where x is each element in the Variable.
+func (v *Variable[T]) Dim(y float64) *Variable[T] {
+	return v.pointwise(func(t nune.Tensor[T]) nune.Tensor[T] {
+		return t.Dim(y)
+	}, func(x, _ float64) float64 {
+		if x > y {
+			return 1
+		}
+		return 0
+	})
+}
+
+// Erf records the error function of each element in the Variable.
+func (v *Variable[T]) Erf() *Variable[T] {
+	return v.pointwise(nune.Tensor[T].Erf, func(x, y float64) float64 {
+		return 2 / math.SqrtPi * math.Exp(-x*x)
+	})
+}
+
+// Erfc records the complementary error function of each element in the Variable.
+func (v *Variable[T]) Erfc() *Variable[T] {
+	return v.pointwise(nune.Tensor[T].Erfc, func(x, y float64) float64 {
+		return -2 / math.SqrtPi * math.Exp(-x*x)
+	})
+}
+
+// Erfcinv records the inverse of Erfc of each element in the Variable.
+func (v *Variable[T]) Erfcinv() *Variable[T] {
+	return v.pointwise(nune.Tensor[T].Erfcinv, func(x, y float64) float64 {
+		return -math.SqrtPi / 2 * math.Exp(y*y)
+	})
+}
+
+// Erfinv records the inverse error function of each element in the Variable.
+func (v *Variable[T]) Erfinv() *Variable[T] {
+	return v.pointwise(nune.Tensor[T].Erfinv, func(x, y float64) float64 {
+		return math.SqrtPi / 2 * math.Exp(y*y)
+	})
+}
+
+// Exp records the base-e exponential of each element in the Variable.
+func (v *Variable[T]) Exp() *Variable[T] {
+	return v.pointwise(nune.Tensor[T].Exp, func(x, y float64) float64 {
+		return y
+	})
+}
+
+// Exp2 records the base-2 exponential of each element in the Variable.
+func (v *Variable[T]) Exp2() *Variable[T] {
+	return v.pointwise(nune.Tensor[T].Exp2, func(x, y float64) float64 {
+		return y * math.Ln2
+	})
+}
+
+// Expm1 records the base-e exponential of each element
+// in the Variable, minus 1.
+func (v *Variable[T]) Expm1() *Variable[T] {
+	return v.pointwise(nune.Tensor[T].Expm1, func(x, y float64) float64 {
+		return y + 1
+	})
+}
+
+// FMA records x * y + z, where x is each element in the Variable.
+func (v *Variable[T]) FMA(y, z float64) *Variable[T] {
+	return v.pointwise(func(t nune.Tensor[T]) nune.Tensor[T] {
+		return t.FMA(y, z)
+	}, func(_, _ float64) float64 {
+		return y
+	})
+}
+
+// Floor records the greatest integer value less than or equal to
+// each element in the Variable, whose gradient is zero.
+func (v *Variable[T]) Floor() *Variable[T] {
+	return v.constant(nune.Tensor[T].Floor)
+}
+
+// Gamma records the Gamma function of each element in the Variable.
+func (v *Variable[T]) Gamma() *Variable[T] {
+	return v.pointwise(nune.Tensor[T].Gamma, func(x, y float64) float64 {
+		return y * digamma(x)
+	})
+}
+
+// Ilogb records the binary exponent of each element in the Variable
+// as an integer, whose gradient is zero.
+func (v *Variable[T]) Ilogb() *Variable[T] {
+	return v.constant(nune.Tensor[T].Ilogb)
+}
+
+// Inf records positive infinity if x >= 0, negative infinity if x < 0,
+// where x is each element in the Variable, whose gradient is zero.
+func (v *Variable[T]) Inf() *Variable[T] {
+	return v.constant(nune.Tensor[T].Inf)
+}
+
+// J0 records the order-zero Bessel function of the first kind
+// for each element in the Variable.
+func (v *Variable[T]) J0() *Variable[T] {
+	return v.pointwise(nune.Tensor[T].J0, func(x, y float64) float64 {
+		return -math.J1(x)
+	})
+}
+
+// J1 records the order-one Bessel function of the first kind
+// for each element in the Variable.
+func (v *Variable[T]) J1() *Variable[T] {
+	return v.pointwise(nune.Tensor[T].J1, func(x, y float64) float64 {
+		return (math.J0(x) - math.Jn(2, x)) / 2
+	})
+}
+
+// Jn records the order-n Bessel function of the first kind
+// for each element in the Variable.
+func (v *Variable[T]) Jn(n int) *Variable[T] {
+	return v.pointwise(func(t nune.Tensor[T]) nune.Tensor[T] {
+		return t.Jn(n)
+	}, func(x, _ float64) float64 {
+		return (math.Jn(n-1, x) - math.Jn(n+1, x)) / 2
+	})
+}
+
+// Log records the natural logarithm of each element in the Variable.
+func (v *Variable[T]) Log() *Variable[T] {
+	return v.pointwise(nune.Tensor[T].Log, func(x, y float64) float64 {
+		return 1 / x
+	})
+}
+
+// Log10 records the decimal logarithm of each element in the Variable.
+func (v *Variable[T]) Log10() *Variable[T] {
+	return v.pointwise(nune.Tensor[T].Log10, func(x, y float64) float64 {
+		return 1 / (x * math.Ln10)
+	})
+}
+
+// Log1p records the natural logarithm of 1 plus
+// each element in the Variable.
+func (v *Variable[T]) Log1p() *Variable[T] {
+	return v.pointwise(nune.Tensor[T].Log1p, func(x, y float64) float64 {
+		return 1 / (1 + x)
+	})
+}
+
+// Log2 records the binary logarithm of each element in the Variable.
+func (v *Variable[T]) Log2() *Variable[T] {
+	return v.pointwise(nune.Tensor[T].Log2, func(x, y float64) float64 {
+		return 1 / (x * math.Ln2)
+	})
+}
+
+// Logb records the binary exponent of each element in the Variable,
+// whose gradient is zero.
+func (v *Variable[T]) Logb() *Variable[T] {
+	return v.constant(nune.Tensor[T].Logb)
+}
+
+// Mod records the floating-point remainder of x/y,
+// where x is each element in the Variable.
+func (v *Variable[T]) Mod(y float64) *Variable[T] {
+	return v.pointwise(func(t nune.Tensor[T]) nune.Tensor[T] {
+		return t.Mod(y)
+	}, func(_, _ float64) float64 {
+		return 1
+	})
+}
+
+// NaN records an IEEE 754 “not-a-number” value for each element
+// in the Variable, whose gradient is zero.
+func (v *Variable[T]) NaN() *Variable[T] {
+	return v.constant(nune.Tensor[T].NaN)
+}
+
+// Nextafter records the next representable float64 value after x
+// towards y, where x is each element in the Variable, whose gradient
+// is zero, since it steps between representable values.
+func (v *Variable[T]) Nextafter(y float64) *Variable[T] {
+	return v.constant(func(t nune.Tensor[T]) nune.Tensor[T] {
+		return t.Nextafter(y)
+	})
+}
+
+// Nextafter32 records the next representable float32 value after x
+// towards y, where x is each element in the Variable, whose gradient
+// is zero, since it steps between representable values.
+func (v *Variable[T]) Nextafter32(y float32) *Variable[T] {
+	return v.constant(func(t nune.Tensor[T]) nune.Tensor[T] {
+		return t.Nextafter32(y)
+	})
+}
+
+// Pow records the base-x exponential of y, where x is each
+// element in the Variable.
+func (v *Variable[T]) Pow(y float64) *Variable[T] {
+	return v.pointwise(func(t nune.Tensor[T]) nune.Tensor[T] {
+		return t.Pow(y)
+	}, func(x, _ float64) float64 {
+		return y * math.Pow(x, y-1)
+	})
+}
+
+// Pow10 records the base-10 exponential of n, for each element
+// in the Variable. The result doesn't depend on the elements,
+// so its gradient is zero.
+func (v *Variable[T]) Pow10(n int) *Variable[T] {
+	return v.constant(func(t nune.Tensor[T]) nune.Tensor[T] {
+		return t.Pow10(n)
+	})
+}
+
+// Remainder records the IEEE 754 floating-point remainder of x/y,
+// where x is each element in the Variable.
+func (v *Variable[T]) Remainder(y float64) *Variable[T] {
+	return v.pointwise(func(t nune.Tensor[T]) nune.Tensor[T] {
+		return t.Remainder(y)
+	}, func(_, _ float64) float64 {
+		return 1
+	})
+}
+
+// Round records the nearest integer of each element in the Variable,
+// rounding half away from zero, whose gradient is zero.
+func (v *Variable[T]) Round() *Variable[T] {
+	return v.constant(nune.Tensor[T].Round)
+}
+
+// RoundToEven records the nearest integer of each element in the Variable,
+// rounding ties to even, whose gradient is zero.
+func (v *Variable[T]) RoundToEven() *Variable[T] {
+	return v.constant(nune.Tensor[T].RoundToEven)
+}
+
+// Sin records the sine of each radian element of the Variable.
+func (v *Variable[T]) Sin() *Variable[T] {
+	return v.pointwise(nune.Tensor[T].Sin, func(x, y float64) float64 {
+		return math.Cos(x)
+	})
+}
+
+// Sinh records the hyperbolic sine of each element in the Variable.
+func (v *Variable[T]) Sinh() *Variable[T] {
+	return v.pointwise(nune.Tensor[T].Sinh, func(x, y float64) float64 {
+		return math.Cosh(x)
+	})
+}
+
+// Sqrt records the square root of each element in the Variable.
+func (v *Variable[T]) Sqrt() *Variable[T] {
+	return v.pointwise(nune.Tensor[T].Sqrt, func(x, y float64) float64 {
+		return 1 / (2 * y)
+	})
+}
+
+// Tan records the tangent of each radian element of the Variable.
+func (v *Variable[T]) Tan() *Variable[T] {
+	return v.pointwise(nune.Tensor[T].Tan, func(x, y float64) float64 {
+		return 1 + y*y
+	})
+}
+
+// Tanh records the hyperbolic tangent of each element in the Variable.
+func (v *Variable[T]) Tanh() *Variable[T] {
+	return v.pointwise(nune.Tensor[T].Tanh, func(x, y float64) float64 {
+		return 1 - y*y
+	})
+}
+
+// Trunc records the integer value of each element in the Variable,
+// whose gradient is zero.
+func (v *Variable[T]) Trunc() *Variable[T] {
+	return v.constant(nune.Tensor[T].Trunc)
+}
+
+// Y0 records the order-zero Bessel function of the second kind
+// for each element in the Variable.
+func (v *Variable[T]) Y0() *Variable[T] {
+	return v.pointwise(nune.Tensor[T].Y0, func(x, y float64) float64 {
+		return -math.Y1(x)
+	})
+}
+
+// Y1 records the order-one Bessel function of the second kind
+// for each element in the Variable.
+func (v *Variable[T]) Y1() *Variable[T] {
+	return v.pointwise(nune.Tensor[T].Y1, func(x, y float64) float64 {
+		return (math.Y0(x) - math.Yn(2, x)) / 2
+	})
+}
+
+// Yn records the order-n Bessel function of the second kind
+// for each element in the Variable.
+func (v *Variable[T]) Yn(n int) *Variable[T] {
+	return v.pointwise(func(t nune.Tensor[T]) nune.Tensor[T] {
+		return t.Yn(n)
+	}, func(x, _ float64) float64 {
+		return (math.Yn(n-1, x) - math.Yn(n+1, x)) / 2
+	})
+}
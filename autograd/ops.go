@@ -0,0 +1,202 @@
+// Copyright © The Nune Author. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package autograd
+
+import (
+	"github.com/vorduin/nune"
+)
+
+// binary records an elementwise operation between the Variable and other,
+// broadcast together, whose result is computed in place by f in a copy
+// of the Variable's value, and whose gradients are computed by df from
+// both operands' values, with the result's gradient g.
+func (v *Variable[T]) binary(other any, f func(x nune.Tensor[T], y any) nune.Tensor[T], df func(g, x, y nune.Tensor[T]) (nune.Tensor[T], nune.Tensor[T])) *Variable[T] {
+	o := v.operand(other)
+	out := f(v.value.Clone(), o.value)
+
+	return v.tape.record(out, []*Variable[T]{v, o}, func(g nune.Tensor[T]) []nune.Tensor[T] {
+		dx, dy := df(g, v.value, o.value)
+		return []nune.Tensor[T]{
+			unbroadcast(dx, v.value.Shape()),
+			unbroadcast(dy, o.value.Shape()),
+		}
+	})
+}
+
+// Add records an elementwise addition between other and the Variable,
+// where other can be a Variable, a Tensor, or any value that can be
+// zipped with a Tensor, which is then recorded as a constant.
+func (v *Variable[T]) Add(other any) *Variable[T] {
+	return v.binary(other, nune.Tensor[T].Add, func(g, x, y nune.Tensor[T]) (nune.Tensor[T], nune.Tensor[T]) {
+		return g, g
+	})
+}
+
+// Sub records an elementwise subtraction between other and the Variable.
+func (v *Variable[T]) Sub(other any) *Variable[T] {
+	return v.binary(other, nune.Tensor[T].Sub, func(g, x, y nune.Tensor[T]) (nune.Tensor[T], nune.Tensor[T]) {
+		return g, g.Clone().Mul(T(-1))
+	})
+}
+
+// Mul records an elementwise multiplication between other and the Variable.
+func (v *Variable[T]) Mul(other any) *Variable[T] {
+	return v.binary(other, nune.Tensor[T].Mul, func(g, x, y nune.Tensor[T]) (nune.Tensor[T], nune.Tensor[T]) {
+		return g.Clone().Mul(y), g.Clone().Mul(x)
+	})
+}
+
+// Div records an elementwise division between other and the Variable.
+func (v *Variable[T]) Div(other any) *Variable[T] {
+	return v.binary(other, nune.Tensor[T].Div, func(g, x, y nune.Tensor[T]) (nune.Tensor[T], nune.Tensor[T]) {
+		dx := g.Clone().Div(y)
+		return dx, dx.Clone().Mul(x).Div(y).Mul(T(-1))
+	})
+}
+
+// MatMul records the matrix product of the Variable and other,
+// both of which must be at least rank 2, with the same batching
+// and broadcasting rules as the Tensors' MatMul.
+func (v *Variable[T]) MatMul(other any) *Variable[T] {
+	o := v.operand(other)
+	out := v.value.MatMul(o.value)
+
+	return v.tape.record(out, []*Variable[T]{v, o}, func(g nune.Tensor[T]) []nune.Tensor[T] {
+		x, y := v.value, o.value
+		dx := g.MatMul(y.Permute(swapLast(y.Rank())...))
+		dy := x.Permute(swapLast(x.Rank())...).MatMul(g)
+
+		return []nune.Tensor[T]{
+			unbroadcast(dx, x.Shape()),
+			unbroadcast(dy, y.Shape()),
+		}
+	})
+}
+
+// unary records an operation on the Variable, whose gradient
+// is computed by df from the result's gradient g.
+func (v *Variable[T]) unary(out nune.Tensor[T], df func(g nune.Tensor[T]) nune.Tensor[T]) *Variable[T] {
+	return v.tape.record(out, []*Variable[T]{v}, func(g nune.Tensor[T]) []nune.Tensor[T] {
+		return []nune.Tensor[T]{df(g)}
+	})
+}
+
+// Sum records the sum of all elements in the Variable.
+func (v *Variable[T]) Sum() *Variable[T] {
+	return v.unary(v.value.Sum(), func(g nune.Tensor[T]) nune.Tensor[T] {
+		return g.Expand(v.value.Shape()...)
+	})
+}
+
+// Mean records the mean value of all elements in the Variable.
+func (v *Variable[T]) Mean() *Variable[T] {
+	n := T(v.value.Numel())
+	out := v.value.Sum().Div(n)
+
+	return v.unary(out, func(g nune.Tensor[T]) nune.Tensor[T] {
+		return g.Clone().Div(n).Expand(v.value.Shape()...)
+	})
+}
+
+// expandAxis returns a view of the gradient of a reduction along
+// the given axis broadcast back to the shape of the reduced Variable.
+func (v *Variable[T]) expandAxis(g nune.Tensor[T], axis int, keepDims bool) nune.Tensor[T] {
+	if !keepDims {
+		g = g.Unsqueeze(axis)
+	}
+
+	return g.Expand(v.value.Shape()...)
+}
+
+// SumAxis records the sum of the elements
+// along the given axis of the Variable.
+func (v *Variable[T]) SumAxis(axis int, keepDims bool) *Variable[T] {
+	return v.unary(v.value.SumAxis(axis, keepDims), func(g nune.Tensor[T]) nune.Tensor[T] {
+		return v.expandAxis(g, axis, keepDims)
+	})
+}
+
+// MeanAxis records the mean value of the elements
+// along the given axis of the Variable.
+func (v *Variable[T]) MeanAxis(axis int, keepDims bool) *Variable[T] {
+	out := v.value.SumAxis(axis, keepDims)
+	if out.Err == nil {
+		out = out.Div(T(v.value.Size(axis)))
+	}
+
+	return v.unary(out, func(g nune.Tensor[T]) nune.Tensor[T] {
+		return v.expandAxis(g.Clone().Div(T(v.value.Size(axis))), axis, keepDims)
+	})
+}
+
+// extremum records a global minimum or maximum of the Variable,
+// whose gradient flows to the elements equal to the result,
+// evenly split between them.
+func (v *Variable[T]) extremum(out nune.Tensor[T]) *Variable[T] {
+	return v.unary(out, func(g nune.Tensor[T]) nune.Tensor[T] {
+		mask := nune.Cast[T](v.value.Eq(out))
+		return mask.Mul(g.Scalar() / mask.Sum().Scalar())
+	})
+}
+
+// Min records the minimum value of all elements in the Variable.
+func (v *Variable[T]) Min() *Variable[T] {
+	return v.extremum(v.value.Min())
+}
+
+// Max records the maximum value of all elements in the Variable.
+func (v *Variable[T]) Max() *Variable[T] {
+	return v.extremum(v.value.Max())
+}
+
+// extremumAxis records the minimums or maximums along the given axis
+// of the Variable, whose gradients flow to the elements of each lane
+// equal to the lane's result, evenly split between them.
+func (v *Variable[T]) extremumAxis(out nune.Tensor[T], axis int, keepDims bool) *Variable[T] {
+	return v.unary(out, func(g nune.Tensor[T]) nune.Tensor[T] {
+		mask := nune.Cast[T](v.value.Eq(v.expandAxis(out, axis, keepDims)))
+		count := mask.SumAxis(axis, true)
+		return mask.Mul(v.expandAxis(g, axis, keepDims)).Div(count)
+	})
+}
+
+// MinAxis records the minimum value of the elements
+// along the given axis of the Variable.
+func (v *Variable[T]) MinAxis(axis int, keepDims bool) *Variable[T] {
+	return v.extremumAxis(v.value.MinAxis(axis, keepDims), axis, keepDims)
+}
+
+// MaxAxis records the maximum value of the elements
+// along the given axis of the Variable.
+func (v *Variable[T]) MaxAxis(axis int, keepDims bool) *Variable[T] {
+	return v.extremumAxis(v.value.MaxAxis(axis, keepDims), axis, keepDims)
+}
+
+// Reshape records a change of the Variable's shape.
+func (v *Variable[T]) Reshape(shape ...int) *Variable[T] {
+	return v.unary(v.value.Reshape(shape...), func(g nune.Tensor[T]) nune.Tensor[T] {
+		return g.Reshape(v.value.Shape()...)
+	})
+}
+
+// Permute records a permutation of the Variable's axes.
+func (v *Variable[T]) Permute(axes ...int) *Variable[T] {
+	return v.unary(v.value.Permute(axes...), func(g nune.Tensor[T]) nune.Tensor[T] {
+		inverse := make([]int, len(axes))
+		for i, axis := range axes {
+			inverse[axis] = i
+		}
+
+		return g.Permute(inverse...)
+	})
+}
+
+// Broadcast records the broadcasting of the Variable to the given shape,
+// whose gradient is summed over the axes the Variable was broadcast along.
+func (v *Variable[T]) Broadcast(shape ...int) *Variable[T] {
+	return v.unary(v.value.Broadcast(shape...), func(g nune.Tensor[T]) nune.Tensor[T] {
+		return unbroadcast(g, v.value.Shape())
+	})
+}
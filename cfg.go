@@ -4,22 +4,104 @@
 
 package nune
 
-// EnvConfig holds Nune's environment configuration.
+// EnvConfig holds Nune's default environment configuration,
+// used by the Tensors that aren't bound to an Engine.
 var EnvConfig = struct {
-	Interactive bool // whether the environment is interactive (panics) or not
-	NumCPU      int // the number of CPUs to use. A value of 0 means auto
+	Interactive   bool      // whether the environment is interactive (panics) or not
+	NumCPU        int       // the number of CPUs to use. A value of 0 means auto
+	Summation     Summation // the summation algorithm used for floating-point sums
+	Deterministic bool      // whether reductions are bit-identical regardless of the CPUs
 }{
-	Interactive: false,
-	NumCPU:      0,
+	Interactive:   false,
+	NumCPU:        0,
+	Summation:     SumPairwise,
+	Deterministic: false,
 }
 
-// FmtConfig holds Nune's formatting configuration.
-var FmtConfig = struct {
+// Summation is an algorithm used to sum floating-point elements.
+// Integer elements are always summed naively, since their sums are exact.
+type Summation int
+
+const (
+	// SumPairwise sums the halves of the elements recursively, down to
+	// small blocks summed naively, which keeps the rounding error growing
+	// logarithmically with the number of elements at nearly no cost.
+	SumPairwise Summation = iota
+
+	// SumKahan sums the elements with Kahan–Babuška compensated summation,
+	// which keeps the rounding error independent of the number of elements,
+	// but is several times slower than the other algorithms.
+	SumKahan
+
+	// SumNaive accumulates the elements one after the other,
+	// which is the fastest but least accurate algorithm.
+	SumNaive
+)
+
+// FmtOptions holds formatting options.
+type FmtOptions struct {
 	Excerpt   int  // limit of the number of elements formatted
 	Precision int  // limit of the number of decimals formatted
 	Btoa      bool // convert bytes to ASCII
-}{
+}
+
+// FmtConfig holds Nune's default formatting configuration,
+// used by the Tensors that aren't bound to an Engine.
+var FmtConfig = FmtOptions{
 	Excerpt:   6,
 	Precision: 4,
 	Btoa:      false,
 }
+
+// An Engine holds a configuration that Tensors can be bound to,
+// in place of the package's global defaults. The Tensors returned
+// by an operation are bound to the same Engine as its receiver,
+// so that a whole chain of operations shares one configuration.
+// An Engine must not be modified while it's being used.
+type Engine struct {
+	Interactive   bool       // whether the engine is interactive (panics) or not
+	NumCPU        int        // the number of CPUs to use. A value of 0 means auto
+	Summation     Summation  // the summation algorithm used for floating-point sums
+	Deterministic bool       // whether reductions are bit-identical regardless of the CPUs
+	Fmt           FmtOptions // the formatting options
+}
+
+// NewEngine returns a new Engine holding
+// a copy of the current global defaults.
+func NewEngine() *Engine {
+	return &Engine{
+		Interactive:   EnvConfig.Interactive,
+		NumCPU:        EnvConfig.NumCPU,
+		Summation:     EnvConfig.Summation,
+		Deterministic: EnvConfig.Deterministic,
+		Fmt:           FmtConfig,
+	}
+}
+
+// WithEngine returns a view of the Tensor bound to the given Engine.
+// A nil Engine binds the view back to the global defaults.
+func (t Tensor[T]) WithEngine(e *Engine) Tensor[T] {
+	t.eng = e
+	return t
+}
+
+// Engine returns the Engine the Tensor is bound to,
+// or nil if it uses the global defaults.
+func (t Tensor[T]) Engine() *Engine {
+	return t.eng
+}
+
+// env returns the configuration in effect for the Tensor.
+func (t Tensor[T]) env() Engine {
+	if t.eng != nil {
+		return *t.eng
+	}
+
+	return Engine{
+		Interactive:   EnvConfig.Interactive,
+		NumCPU:        EnvConfig.NumCPU,
+		Summation:     EnvConfig.Summation,
+		Deterministic: EnvConfig.Deterministic,
+		Fmt:           FmtConfig,
+	}
+}
@@ -0,0 +1,284 @@
+// Copyright © The Nune Author. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package nune
+
+import (
+	"github.com/vorduin/slices"
+)
+
+// asTensor returns the value as a Tensor of the given numeric type,
+// without copying it if it already is one.
+func asTensor[T Number](x any) Tensor[T] {
+	if t, ok := x.(Tensor[T]); ok {
+		return t
+	}
+
+	return From[T](x)
+}
+
+// Compare performs an elementwise comparison between this and the
+// other Tensor, broadcast together, and returns a mask holding 1
+// where the comparison holds and 0 elsewhere.
+func (t Tensor[T]) Compare(other any, f func(T, T) bool) Tensor[byte] {
+	if t.Err != nil {
+		if t.env().Interactive {
+			panic(t.Err)
+		} else {
+			return Tensor[byte]{
+				Err: t.Err,
+			}
+		}
+	}
+
+	o := asTensor[T](other)
+
+	shape, err := broadcastShapes(t.shape, o.shape)
+	if o.Err != nil {
+		err = o.Err
+	} else if err != nil {
+		err = newOpError("Compare", err, -1, t.shape, o.shape)
+	}
+	if err != nil {
+		if t.env().Interactive {
+			panic(err)
+		} else {
+			return Tensor[byte]{
+				Err: err,
+			}
+		}
+	}
+
+	lhs, rhs := t.Expand(shape...), o.Expand(shape...)
+	out := slices.WithLen[byte](slices.Prod(shape))
+
+	offsets := []int{lhs.offset, rhs.offset}
+	strides := [][]int{lhs.stride, rhs.stride}
+
+	handleViews(shape, offsets, strides, t.env().configCPU(len(out)), func(i int, pos, step []int, l int) {
+		for j := 0; j < l; j++ {
+			if f(lhs.data[pos[0]], rhs.data[pos[1]]) {
+				out[i+j] = 1
+			}
+			pos[0] += step[0]
+			pos[1] += step[1]
+		}
+	})
+
+	return Tensor[byte]{
+		data:   out,
+		shape:  shape,
+		stride: configStride(shape),
+		eng:    t.eng,
+	}
+}
+
+// Eq returns a mask of where this Tensor's elements
+// are equal to the other Tensor's elements.
+func (t Tensor[T]) Eq(other any) Tensor[byte] {
+	return t.Compare(other, func(x, y T) bool {
+		return x == y
+	})
+}
+
+// Ne returns a mask of where this Tensor's elements
+// are not equal to the other Tensor's elements.
+func (t Tensor[T]) Ne(other any) Tensor[byte] {
+	return t.Compare(other, func(x, y T) bool {
+		return x != y
+	})
+}
+
+// Lt returns a mask of where this Tensor's elements
+// are less than the other Tensor's elements.
+func (t Tensor[T]) Lt(other any) Tensor[byte] {
+	return t.Compare(other, func(x, y T) bool {
+		return x < y
+	})
+}
+
+// Le returns a mask of where this Tensor's elements
+// are less than or equal to the other Tensor's elements.
+func (t Tensor[T]) Le(other any) Tensor[byte] {
+	return t.Compare(other, func(x, y T) bool {
+		return x <= y
+	})
+}
+
+// Gt returns a mask of where this Tensor's elements
+// are greater than the other Tensor's elements.
+func (t Tensor[T]) Gt(other any) Tensor[byte] {
+	return t.Compare(other, func(x, y T) bool {
+		return x > y
+	})
+}
+
+// Ge returns a mask of where this Tensor's elements
+// are greater than or equal to the other Tensor's elements.
+func (t Tensor[T]) Ge(other any) Tensor[byte] {
+	return t.Compare(other, func(x, y T) bool {
+		return x >= y
+	})
+}
+
+// Where returns a Tensor holding the elements of a where the mask
+// is not 0, and the elements of b elsewhere, where the mask, a and b
+// are broadcast together.
+func Where[T Number](mask Tensor[byte], a, b any) Tensor[T] {
+	x, y := asTensor[T](a), asTensor[T](b)
+
+	var err error
+	var shape []int
+
+	switch {
+	case mask.Err != nil:
+		err = mask.Err
+	case x.Err != nil:
+		err = x.Err
+	case y.Err != nil:
+		err = y.Err
+	default:
+		shape, err = broadcastShapes(mask.shape, x.shape)
+		if err == nil {
+			shape, err = broadcastShapes(shape, y.shape)
+		}
+		if err != nil {
+			err = newOpError("Where", err, -1, mask.shape, x.shape, y.shape)
+		}
+	}
+
+	if err != nil {
+		if mask.env().Interactive {
+			panic(err)
+		} else {
+			return Tensor[T]{
+				Err: err,
+			}
+		}
+	}
+
+	mask, x, y = mask.Expand(shape...), x.Expand(shape...), y.Expand(shape...)
+	out := slices.WithLen[T](slices.Prod(shape))
+
+	offsets := []int{mask.offset, x.offset, y.offset}
+	strides := [][]int{mask.stride, x.stride, y.stride}
+
+	handleViews(shape, offsets, strides, mask.env().configCPU(len(out)), func(i int, pos, step []int, l int) {
+		for j := 0; j < l; j++ {
+			if mask.data[pos[0]] != 0 {
+				out[i+j] = x.data[pos[1]]
+			} else {
+				out[i+j] = y.data[pos[2]]
+			}
+			pos[0] += step[0]
+			pos[1] += step[1]
+			pos[2] += step[2]
+		}
+	})
+
+	return Tensor[T]{
+		data:   out,
+		shape:  shape,
+		stride: configStride(shape),
+		eng:    mask.eng,
+	}
+}
+
+// MaskedFill sets the Tensor's elements to the given value
+// where the mask, broadcast to the Tensor's shape, is not 0.
+func (t Tensor[T]) MaskedFill(mask Tensor[byte], x T) Tensor[T] {
+	if t.Err != nil {
+		if t.env().Interactive {
+			panic(t.Err)
+		} else {
+			return t
+		}
+	}
+
+	if t, ok := t.writable("MaskedFill"); !ok {
+		return t
+	}
+
+	mask = mask.Expand(t.shape...)
+	if mask.Err != nil {
+		if t.env().Interactive {
+			panic(mask.Err)
+		} else {
+			t.Err = mask.Err
+			return t
+		}
+	}
+
+	offsets := []int{t.offset, mask.offset}
+	strides := [][]int{t.stride, mask.stride}
+
+	handleViews(t.shape, offsets, strides, t.env().configCPU(t.Numel()), func(_ int, pos, step []int, l int) {
+		for j := 0; j < l; j++ {
+			if mask.data[pos[1]] != 0 {
+				t.data[pos[0]] = x
+			}
+			pos[0] += step[0]
+			pos[1] += step[1]
+		}
+	})
+
+	return t
+}
+
+// MaskedSelect returns a rank 1 Tensor holding, in row-major order,
+// the Tensor's elements where the mask, broadcast to the Tensor's
+// shape, is not 0. Since Tensors cannot be empty, selecting
+// no elements at all results in ErrBadShape.
+func (t Tensor[T]) MaskedSelect(mask Tensor[byte]) Tensor[T] {
+	if t.Err != nil {
+		if t.env().Interactive {
+			panic(t.Err)
+		} else {
+			return t
+		}
+	}
+
+	mask = mask.Expand(t.shape...)
+	if mask.Err != nil {
+		if t.env().Interactive {
+			panic(mask.Err)
+		} else {
+			t.Err = mask.Err
+			return t
+		}
+	}
+
+	var out []T
+
+	offsets := []int{t.offset, mask.offset}
+	strides := [][]int{t.stride, mask.stride}
+
+	handleViews(t.shape, offsets, strides, 1, func(_ int, pos, step []int, l int) {
+		for j := 0; j < l; j++ {
+			if mask.data[pos[1]] != 0 {
+				out = append(out, t.data[pos[0]])
+			}
+			pos[0] += step[0]
+			pos[1] += step[1]
+		}
+	})
+
+	err := verifyGoodShape(len(out))
+	if err != nil {
+		err = newOpError("MaskedSelect", err, -1, t.shape)
+		if t.env().Interactive {
+			panic(err)
+		} else {
+			t.Err = err
+			return t
+		}
+	}
+
+	return Tensor[T]{
+		data:   out,
+		shape:  []int{len(out)},
+		stride: []int{1},
+		eng:    t.eng,
+	}
+}
@@ -0,0 +1,65 @@
+// Copyright © The Nune Author. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package nune_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/vorduin/nune"
+)
+
+func BenchmarkEq(b *testing.B) {
+	tensor := newTensor()
+
+	benchmarkOp(b, func() {
+		tensor.Eq(tensor)
+	})
+}
+
+func BenchmarkGt(b *testing.B) {
+	tensor := newTensor()
+
+	benchmarkOp(b, func() {
+		tensor.Gt(5e6)
+	})
+}
+
+func BenchmarkWhere(b *testing.B) {
+	tensor := newTensor()
+	mask := tensor.Gt(5e6)
+
+	benchmarkOp(b, func() {
+		nune.Where[float64](mask, tensor, 0)
+	})
+}
+
+func BenchmarkMaskedFill(b *testing.B) {
+	tensor := newTensor()
+	mask := tensor.Gt(5e6)
+
+	benchmarkOp(b, func() {
+		tensor.MaskedFill(mask, 0)
+	})
+}
+
+func BenchmarkMaskedSelect(b *testing.B) {
+	tensor := newTensor()
+	mask := tensor.Gt(5e6)
+
+	benchmarkOp(b, func() {
+		tensor.MaskedSelect(mask)
+	})
+}
+
+func TestMaskedSelectEmpty(t *testing.T) {
+	tensor := nune.Range[float64](0, 6, 1).Reshape(2, 3)
+	out := tensor.MaskedSelect(nune.Zeros[byte](3))
+
+	var e *nune.OpError
+	if !errors.As(out.Err, &e) || e.Op != "MaskedSelect" || !errors.Is(out.Err, nune.ErrBadShape) {
+		t.Fatalf("MaskedSelect: got error %v, want a MaskedSelect OpError wrapping %v", out.Err, nune.ErrBadShape)
+	}
+}
@@ -88,7 +88,7 @@ func Full[T Number](x T, shape []int) Tensor[T] {
 }
 
 // FullLike returns a Tensor full with the given value and
-// resembling the other Tensor's shape.
+// resembling the other Tensor's shape and bound to its Engine.
 func FullLike[T Number, U Number](x T, other Tensor[U]) Tensor[T] {
 	data := slices.WithLen[T](other.Numel())
 	for i := 0; i < len(data); i++ {
@@ -99,6 +99,7 @@ func FullLike[T Number, U Number](x T, other Tensor[U]) Tensor[T] {
 		data:   data,
 		shape:  slices.Clone(other.shape),
 		stride: configStride(other.shape),
+		eng:    other.eng,
 	}
 }
 
@@ -122,13 +123,14 @@ func Zeros[T Number](shape ...int) Tensor[T] {
 	}
 }
 
-// ZerosLike returns a Tensor full with zeros and resembling the other
-// Tensor's shape.
+// ZerosLike returns a Tensor full with zeros, resembling the other
+// Tensor's shape and bound to its Engine.
 func ZerosLike[T Number, U Number](other Tensor[U]) Tensor[T] {
 	return Tensor[T]{
 		data:   slices.WithLen[T](other.Numel()),
 		shape:  slices.Clone(other.shape),
 		stride: configStride(other.shape),
+		eng:    other.eng,
 	}
 }
 
@@ -137,8 +139,8 @@ func Ones[T Number](shape ...int) Tensor[T] {
 	return Full(T(1), shape)
 }
 
-// OnesLike returns a Tensor full with ones and resembling the other
-// Tensor's shape.
+// OnesLike returns a Tensor full with ones, resembling the other
+// Tensor's shape and bound to its Engine.
 func OnesLike[T Number, U Number](other Tensor[U]) Tensor[T] {
 	return FullLike(T(1), other)
 }
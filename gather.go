@@ -0,0 +1,426 @@
+// Copyright © The Nune Author. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package nune
+
+import (
+	"github.com/vorduin/slices"
+)
+
+// handleViewLanes concurrently walks over views sharing the same shape
+// as handleViews does, except that the views are walked over one lane
+// along the given axis at a time, where each share of the lanes is
+// walked over concurrently, and each lane is walked over in order.
+func handleViewLanes(shape []int, axis int, offsets []int, strides [][]int, nCPU int, f func(pos, step []int, l int)) {
+	// move the axis last, so that each lane is a run of elements
+	last := func(s []int) []int {
+		p := slices.WithCap[int](len(s))
+		p = append(p, s[:axis]...)
+		p = append(p, s[axis+1:]...)
+		return append(p, s[axis])
+	}
+
+	n := shape[axis]
+	lanes := slices.Prod(shape) / n
+
+	shape = last(shape)
+	for k := range strides {
+		strides[k] = last(strides[k])
+	}
+
+	shape, strides = coalesce(shape, strides...)
+
+	parallelize(lanes, nCPU, func(_, min, max int) {
+		walkers := make([]*walker, len(strides))
+		for k := range walkers {
+			walkers[k] = newWalker(shape, strides[k], offsets[k], min*n)
+		}
+
+		pos := slices.WithLen[int](len(walkers))
+		step := slices.WithLen[int](len(walkers))
+
+		for i := min * n; i < max*n; {
+			var l int
+			for k, w := range walkers {
+				pos[k], step[k], l = w.run(max*n - i)
+			}
+
+			f(pos, step, l)
+			i += l
+		}
+	})
+}
+
+// verifyIndices makes sure the indices all fall within [0, n),
+// where each share of the indices is checked concurrently.
+func verifyIndices(idx Tensor[int], n int) error {
+	shares := idx.env().configCPU(idx.Numel())
+	bad := make([]bool, shares)
+
+	handleShares(idx, func(i int, s []int) {
+		for _, x := range s {
+			if x < 0 || x >= n {
+				bad[i] = true
+				return
+			}
+		}
+	}, shares)
+
+	for _, b := range bad {
+		if b {
+			return ErrAxisBounds
+		}
+	}
+
+	return nil
+}
+
+// verifyGather makes sure the axis is within the Tensor's rank, that
+// the indices have the same rank as the Tensor, with dimensions at most
+// the Tensor's along the other axes, and that they index the axis.
+func (t Tensor[T]) verifyGather(op string, axis int, idx Tensor[int]) (Tensor[T], bool) {
+	if idx.Err != nil {
+		err := newOpError(op, idx.Err, axis, t.shape)
+		if t.env().Interactive {
+			panic(err)
+		} else {
+			t.Err = err
+			return t, false
+		}
+	}
+
+	err := verifyAxisBounds(axis, t.Rank()-1)
+	if err == nil && idx.Rank() != t.Rank() {
+		err = ErrShapeMismatch
+	}
+	if err == nil {
+		for i := range t.shape {
+			if i != axis && idx.shape[i] > t.shape[i] {
+				err = ErrShapeMismatch
+			}
+		}
+	}
+	if err == nil {
+		err = verifyIndices(idx, t.shape[axis])
+	}
+
+	if err != nil {
+		err = newOpError(op, err, axis, t.shape, idx.shape)
+		if t.env().Interactive {
+			panic(err)
+		} else {
+			t.Err = err
+			return t, false
+		}
+	}
+
+	return t, true
+}
+
+// IndexSelect returns the elements at the given indices along the given
+// axis of the Tensor, where the axis is replaced by the indices' axes
+// in the returned Tensor's shape, as with NumPy's take along an axis.
+// For instance, selecting the rows of a (vocab, dim) Tensor of embeddings
+// with (batch, seq) indices returns a (batch, seq, dim) Tensor.
+func (t Tensor[T]) IndexSelect(axis int, idx Tensor[int]) Tensor[T] {
+	if t.Err != nil {
+		if t.env().Interactive {
+			panic(t.Err)
+		} else {
+			return t
+		}
+	}
+
+	err := idx.Err
+	if err == nil {
+		err = verifyAxisBounds(axis, t.Rank()-1)
+	}
+	if err == nil {
+		err = verifyIndices(idx, t.shape[axis])
+	}
+
+	if err != nil {
+		err = newOpError("IndexSelect", err, axis, t.shape, idx.shape)
+		if t.env().Interactive {
+			panic(err)
+		} else {
+			t.Err = err
+			return t
+		}
+	}
+
+	rank := t.Rank() - 1 + idx.Rank()
+	shape := slices.WithCap[int](rank)
+	shape = append(shape, t.shape[:axis]...)
+	shape = append(shape, idx.shape...)
+	shape = append(shape, t.shape[axis+1:]...)
+
+	// the Tensor's and the indices' views broadcast to the output's shape
+	tstride := slices.WithLen[int](rank)
+	copy(tstride, t.stride[:axis])
+	copy(tstride[axis+idx.Rank():], t.stride[axis+1:])
+
+	istride := slices.WithLen[int](rank)
+	copy(istride[axis:], idx.stride)
+
+	out := slices.WithLen[T](slices.Prod(shape))
+
+	offsets := []int{0, t.offset, idx.offset}
+	strides := [][]int{configStride(shape), tstride, istride}
+
+	handleViews(shape, offsets, strides, t.env().configCPU(len(out)), func(_ int, pos, step []int, l int) {
+		for j := 0; j < l; j++ {
+			out[pos[0]] = t.data[pos[1]+idx.data[pos[2]]*t.stride[axis]]
+			pos[0] += step[0]
+			pos[1] += step[1]
+			pos[2] += step[2]
+		}
+	})
+
+	return Tensor[T]{
+		data:   out,
+		shape:  shape,
+		stride: configStride(shape),
+		eng:    t.eng,
+	}
+}
+
+// Take returns the elements at the given indices of the Tensor,
+// as if it were flattened in row-major order, with the indices' shape.
+func (t Tensor[T]) Take(idx Tensor[int]) Tensor[T] {
+	if t.Err != nil {
+		if t.env().Interactive {
+			panic(t.Err)
+		} else {
+			return t
+		}
+	}
+
+	err := idx.Err
+	if err == nil {
+		err = verifyIndices(idx, t.Numel())
+	}
+
+	if err != nil {
+		err = newOpError("Take", err, -1, t.shape, idx.shape)
+		if t.env().Interactive {
+			panic(err)
+		} else {
+			t.Err = err
+			return t
+		}
+	}
+
+	data := t.Contiguous().Ravel()
+	out := slices.WithLen[T](idx.Numel())
+
+	offsets := []int{0, idx.offset}
+	strides := [][]int{configStride(idx.shape), idx.stride}
+
+	handleViews(idx.shape, offsets, strides, t.env().configCPU(len(out)), func(_ int, pos, step []int, l int) {
+		for j := 0; j < l; j++ {
+			out[pos[0]] = data[idx.data[pos[1]]]
+			pos[0] += step[0]
+			pos[1] += step[1]
+		}
+	})
+
+	return Tensor[T]{
+		data:   out,
+		shape:  slices.Clone(idx.shape),
+		stride: configStride(idx.shape),
+		eng:    t.eng,
+	}
+}
+
+// Gather returns the elements of the Tensor along the given axis
+// at the given indices, which have the same rank as the Tensor, where
+// the returned Tensor has the indices' shape. For a rank 3 Tensor
+// gathered along axis 1, out[i][j][k] = t[i][idx[i][j][k]][k].
+func (t Tensor[T]) Gather(axis int, idx Tensor[int]) Tensor[T] {
+	if t.Err != nil {
+		if t.env().Interactive {
+			panic(t.Err)
+		} else {
+			return t
+		}
+	}
+
+	if t, ok := t.verifyGather("Gather", axis, idx); !ok {
+		return t
+	}
+
+	// the Tensor's view over the indices' shape, without the axis
+	tstride := slices.Clone(t.stride)
+	tstride[axis] = 0
+
+	out := slices.WithLen[T](idx.Numel())
+
+	offsets := []int{0, t.offset, idx.offset}
+	strides := [][]int{configStride(idx.shape), tstride, idx.stride}
+
+	handleViews(idx.shape, offsets, strides, t.env().configCPU(len(out)), func(_ int, pos, step []int, l int) {
+		for j := 0; j < l; j++ {
+			out[pos[0]] = t.data[pos[1]+idx.data[pos[2]]*t.stride[axis]]
+			pos[0] += step[0]
+			pos[1] += step[1]
+			pos[2] += step[2]
+		}
+	})
+
+	return Tensor[T]{
+		data:   out,
+		shape:  slices.Clone(idx.shape),
+		stride: configStride(idx.shape),
+		eng:    t.eng,
+	}
+}
+
+// scatter writes the source, broadcast to the indices' shape, into the
+// Tensor's elements along the given axis at the given indices, in place,
+// combining each element with its source value through f.
+func (t Tensor[T]) scatter(op string, axis int, idx Tensor[int], src any, f func(x, y T) T) Tensor[T] {
+	if t.Err != nil {
+		if t.env().Interactive {
+			panic(t.Err)
+		} else {
+			return t
+		}
+	}
+
+	if t, ok := t.writable(op); !ok {
+		return t
+	}
+
+	if t, ok := t.verifyGather(op, axis, idx); !ok {
+		return t
+	}
+
+	s := asTensor[T](src)
+
+	err := s.Err
+	if err == nil && !s.Broadable(idx.shape...) {
+		err = ErrNotBroadable
+	}
+
+	if err != nil {
+		err = newOpError(op, err, axis, t.shape, idx.shape)
+		if t.env().Interactive {
+			panic(err)
+		} else {
+			t.Err = err
+			return t
+		}
+	}
+
+	s = s.Expand(idx.shape...)
+
+	// the Tensor's view over the indices' shape, without the axis
+	tstride := slices.Clone(t.stride)
+	tstride[axis] = 0
+
+	offsets := []int{t.offset, idx.offset, s.offset}
+	strides := [][]int{tstride, idx.stride, s.stride}
+
+	// the lanes along the axis are scattered into distinct lanes of the
+	// Tensor, so that repeated indices are only written to sequentially
+	handleViewLanes(idx.shape, axis, offsets, strides, t.env().configCPU(idx.Numel()), func(pos, step []int, l int) {
+		for j := 0; j < l; j++ {
+			p := pos[0] + idx.data[pos[1]]*t.stride[axis]
+			t.data[p] = f(t.data[p], s.data[pos[2]])
+			pos[0] += step[0]
+			pos[1] += step[1]
+			pos[2] += step[2]
+		}
+	})
+
+	return t
+}
+
+// Scatter writes the source, be it a numeric type, a sequence, nested
+// sequences or a Tensor, broadcast to the indices' shape, into the
+// Tensor's elements along the given axis at the given indices, which
+// have the same rank as the Tensor. The elements are written in place,
+// and for a rank 3 Tensor scattered along axis 1,
+// t[i][idx[i][j][k]][k] = src[i][j][k].
+// When an index is repeated along the axis, its last source value is kept.
+func (t Tensor[T]) Scatter(axis int, idx Tensor[int], src any) Tensor[T] {
+	return t.scatter("Scatter", axis, idx, src, func(_, y T) T {
+		return y
+	})
+}
+
+// ScatterAdd adds the source, broadcast to the indices' shape, to the
+// Tensor's elements along the given axis at the given indices, in place,
+// as Scatter does, where the values of repeated indices accumulate.
+func (t Tensor[T]) ScatterAdd(axis int, idx Tensor[int], src any) Tensor[T] {
+	return t.scatter("ScatterAdd", axis, idx, src, func(x, y T) T {
+		return x + y
+	})
+}
+
+// Put writes the source, broadcast to the indices' shape, into the
+// Tensor's elements at the given indices, in place, as if the Tensor
+// were flattened in row-major order. Since repeated indices could be
+// written to by different shares, the elements are written sequentially,
+// so that the last source value of a repeated index is kept.
+func (t Tensor[T]) Put(idx Tensor[int], src any) Tensor[T] {
+	if t.Err != nil {
+		if t.env().Interactive {
+			panic(t.Err)
+		} else {
+			return t
+		}
+	}
+
+	if t, ok := t.writable("Put"); !ok {
+		return t
+	}
+
+	err := idx.Err
+	if err == nil {
+		err = verifyIndices(idx, t.Numel())
+	}
+
+	var s Tensor[T]
+	if err == nil {
+		s = asTensor[T](src)
+		err = s.Err
+	}
+	if err == nil && !s.Broadable(idx.shape...) {
+		err = ErrNotBroadable
+	}
+
+	if err != nil {
+		err = newOpError("Put", err, -1, t.shape, idx.shape)
+		if t.env().Interactive {
+			panic(err)
+		} else {
+			t.Err = err
+			return t
+		}
+	}
+
+	s = s.Expand(idx.shape...)
+
+	offsets := []int{idx.offset, s.offset}
+	strides := [][]int{idx.stride, s.stride}
+
+	handleViews(idx.shape, offsets, strides, 1, func(_ int, pos, step []int, l int) {
+		for j := 0; j < l; j++ {
+			// unravel the index over the Tensor's view
+			p, r := t.offset, idx.data[pos[0]]
+			for k := len(t.shape) - 1; k >= 0; k-- {
+				p += (r % t.shape[k]) * t.stride[k]
+				r /= t.shape[k]
+			}
+
+			t.data[p] = s.data[pos[1]]
+			pos[0] += step[0]
+			pos[1] += step[1]
+		}
+	})
+
+	return t
+}
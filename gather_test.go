@@ -0,0 +1,60 @@
+// Copyright © The Nune Author. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package nune_test
+
+import (
+	"testing"
+
+	"github.com/vorduin/nune"
+)
+
+// newIndices returns a Tensor of the given shape holding
+// pseudo-random indices in the interval [0, n).
+func newIndices(n int, shape ...int) nune.Tensor[int] {
+	numel := 1
+	for _, s := range shape {
+		numel *= s
+	}
+
+	return nune.Range[int](0, numel, 1).Map(func(x int) int {
+		return (x * 7919) % n
+	}).Reshape(shape...)
+}
+
+func BenchmarkIndexSelect(b *testing.B) {
+	tensor := newTensor().Reshape(1e4, 1e3)
+	idx := newIndices(1e4, 100, 100)
+
+	benchmarkOp(b, func() {
+		tensor.IndexSelect(0, idx)
+	})
+}
+
+func BenchmarkTake(b *testing.B) {
+	tensor := newTensor()
+	idx := newIndices(1e7, 1e7)
+
+	benchmarkOp(b, func() {
+		tensor.Take(idx)
+	})
+}
+
+func BenchmarkGather(b *testing.B) {
+	tensor := newTensor().Reshape(1e4, 1e3)
+	idx := newIndices(1e3, 1e4, 1e3)
+
+	benchmarkOp(b, func() {
+		tensor.Gather(1, idx)
+	})
+}
+
+func BenchmarkScatterAdd(b *testing.B) {
+	tensor := newTensor().Reshape(1e4, 1e3)
+	idx := newIndices(1e4, 1e4, 1e3)
+
+	benchmarkOp(b, func() {
+		tensor.ScatterAdd(0, idx, 1)
+	})
+}
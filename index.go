@@ -0,0 +1,270 @@
+// Copyright © The Nune Author. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package nune
+
+import (
+	"github.com/vorduin/slices"
+)
+
+// A Span selects the indices of an axis from its start, inclusive,
+// up to its end, exclusive, every step indices. Negative bounds count
+// from the end of the axis, bounds beyond the axis are clipped to it,
+// and a negative step walks over the axis backwards.
+type Span struct {
+	start, end       int
+	step             int
+	hasStart, hasEnd bool  // whether the bounds are given, or open
+	err              error // the error of the Span's construction, if any
+}
+
+// R returns a Span from start up to end, with an optional step,
+// which defaults to 1.
+func R(start, end int, step ...int) Span {
+	r := RStep(1)
+	if len(step) > 0 {
+		r = RStep(step[0])
+	}
+
+	r.start, r.hasStart = start, true
+	r.end, r.hasEnd = end, true
+	r.err = verifyArgsBounds(len(step), 1)
+
+	return r
+}
+
+// RFrom returns a Span from start up to the end of the axis,
+// with an optional step, which defaults to 1.
+// With a negative step, the Span goes down to the axis' beginning.
+func RFrom(start int, step ...int) Span {
+	r := RStep(1)
+	if len(step) > 0 {
+		r = RStep(step[0])
+	}
+
+	r.start, r.hasStart = start, true
+	r.err = verifyArgsBounds(len(step), 1)
+
+	return r
+}
+
+// RTo returns a Span from the beginning of the axis up to end,
+// with an optional step, which defaults to 1.
+// With a negative step, the Span starts from the axis' end.
+func RTo(end int, step ...int) Span {
+	r := RStep(1)
+	if len(step) > 0 {
+		r = RStep(step[0])
+	}
+
+	r.end, r.hasEnd = end, true
+	r.err = verifyArgsBounds(len(step), 1)
+
+	return r
+}
+
+// RStep returns a Span over the whole axis every step indices.
+// With a negative step, the Span walks over the axis backwards.
+func RStep(step int) Span {
+	return Span{
+		step: step,
+	}
+}
+
+// All is a Span over the whole axis.
+var All = RStep(1)
+
+// indices returns the first index, the number of indices and the
+// step of the Span over an axis of the given dimensions.
+func (r Span) indices(n int) (start, length, step int, err error) {
+	if r.err != nil {
+		return 0, 0, 0, r.err
+	}
+
+	step = r.step
+	if step == 0 {
+		return 0, 0, 0, ErrBadStep
+	}
+
+	// the bounds are clipped to [lower, upper], which is
+	// shifted down by one to walk the axis backwards
+	lower, upper := 0, n
+	if step < 0 {
+		lower, upper = -1, n-1
+	}
+
+	clip := func(x int) int {
+		if x < 0 {
+			x += n
+			if x < lower {
+				x = lower
+			}
+		} else if x > upper {
+			x = upper
+		}
+		return x
+	}
+
+	start, end := lower, upper
+	if step < 0 {
+		start, end = upper, lower
+	}
+
+	if r.hasStart {
+		start = clip(r.start)
+	}
+	if r.hasEnd {
+		end = clip(r.end)
+	}
+
+	if step > 0 && start < end {
+		length = (end-start-1)/step + 1
+	} else if step < 0 && start > end {
+		length = (start-end-1)/-step + 1
+	}
+
+	// Tensors cannot be empty
+	if length == 0 {
+		return 0, 0, 0, ErrBadInterval
+	}
+
+	return start, length, step, nil
+}
+
+// A Marker is a special index given to the S method.
+type Marker int
+
+const (
+	// Ellipsis stands for as many All indices as
+	// needed for the indices to cover every axis.
+	Ellipsis Marker = iota
+
+	// NewAxis inserts a new axis of dimensions 1.
+	NewAxis
+)
+
+// S returns a view over the Tensor indexed by the given indices, one
+// per axis, as with NumPy's basic indexing. An int selects an index of
+// its axis, removing the axis, and counts from the end if it's negative.
+// A Span selects a range of indices of its axis, walking over the axis
+// with the Span's step. The Ellipsis Marker stands for All the axes
+// that aren't indexed, and the NewAxis Marker inserts an axis of
+// dimensions 1. The axes left over by the indices are kept whole.
+func (t Tensor[T]) S(indices ...any) Tensor[T] {
+	if t.Err != nil {
+		if t.env().Interactive {
+			panic(t.Err)
+		} else {
+			return t
+		}
+	}
+
+	// the number of axes consumed by the indices
+	consumed, ellipses := 0, 0
+
+	for _, idx := range indices {
+		var err error
+
+		switch idx := idx.(type) {
+		case int, Span:
+			consumed++
+		case Marker:
+			if idx == Ellipsis {
+				ellipses++
+			} else if idx != NewAxis {
+				err = ErrBadIndex
+			}
+		default:
+			err = ErrBadIndex
+		}
+
+		if err == nil && ellipses > 1 {
+			err = ErrBadIndex
+		}
+
+		if err != nil {
+			err = newOpError("S", err, -1, t.shape)
+			if t.env().Interactive {
+				panic(err)
+			} else {
+				t.Err = err
+				return t
+			}
+		}
+	}
+
+	err := verifyArgsBounds(consumed, len(t.shape))
+	if err != nil {
+		err = newOpError("S", err, -1, t.shape)
+		if t.env().Interactive {
+			panic(err)
+		} else {
+			t.Err = err
+			return t
+		}
+	}
+
+	shape := slices.WithCap[int](len(t.shape) + len(indices))
+	stride := slices.WithCap[int](len(t.shape) + len(indices))
+	offset := t.offset
+
+	axis := 0
+	for _, idx := range indices {
+		switch idx := idx.(type) {
+		case int:
+			if idx < 0 {
+				idx += t.shape[axis]
+			}
+
+			err = verifyAxisBounds(idx, t.shape[axis]-1)
+			if err == nil {
+				offset += idx * t.stride[axis]
+			}
+		case Span:
+			var start, n, step int
+
+			start, n, step, err = idx.indices(t.shape[axis])
+			if err == nil {
+				offset += start * t.stride[axis]
+				shape = append(shape, n)
+				stride = append(stride, step*t.stride[axis])
+			}
+		case Marker:
+			if idx == NewAxis {
+				shape = append(shape, 1)
+				stride = append(stride, 0)
+				continue
+			}
+
+			n := len(t.shape) - consumed
+			shape = append(shape, t.shape[axis:axis+n]...)
+			stride = append(stride, t.stride[axis:axis+n]...)
+			axis += n
+			continue
+		}
+
+		if err != nil {
+			err = newOpError("S", err, axis, t.shape)
+			if t.env().Interactive {
+				panic(err)
+			} else {
+				t.Err = err
+				return t
+			}
+		}
+
+		axis++
+	}
+
+	shape = append(shape, t.shape[axis:]...)
+	stride = append(stride, t.stride[axis:]...)
+
+	return Tensor[T]{
+		data:   t.data,
+		shape:  shape,
+		stride: stride,
+		offset: offset,
+		eng:    t.eng,
+	}
+}
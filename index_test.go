@@ -0,0 +1,27 @@
+// Copyright © The Nune Author. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package nune_test
+
+import (
+	"testing"
+
+	"github.com/vorduin/nune"
+)
+
+func BenchmarkS1e6(b *testing.B) {
+	tensor := nune.Range[float64](0, 1e6, 1).Reshape(10, 200, 500)
+
+	benchmarkMicro(b, func() {
+		tensor.S(nune.R(1, -1, 2), nune.All, nune.Ellipsis, nune.NewAxis)
+	})
+}
+
+func BenchmarkSClone(b *testing.B) {
+	tensor := newTensor().Reshape(1e4, 1e3)
+
+	benchmarkOp(b, func() {
+		tensor.S(nune.RStep(-1), nune.RStep(2)).Clone()
+	})
+}
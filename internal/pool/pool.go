@@ -0,0 +1,226 @@
+// Copyright © The Nune Author. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package pool implements the persistent work-stealing worker pool
+// shared by the parallel operations of Nune and of its subpackages.
+package pool
+
+import (
+	"runtime"
+	"sync"
+	"sync/atomic"
+)
+
+// A chunkRange is a range of chunks of a job
+// that are yet to be claimed by a worker.
+type chunkRange struct {
+	next, end int64
+	_         [48]byte // padding against false sharing
+}
+
+// A job is a parallel call split into chunks, whose participants
+// each own a range of chunks and steal from the others' ranges
+// once theirs is exhausted.
+type job struct {
+	size, n int                   // the size of the interval, and the number of chunks
+	f       func(i, min, max int) // the function called on each chunk
+	ranges  []chunkRange          // the ranges of chunks owned by the participants
+	wg      sync.WaitGroup        // waits for all the chunks to be done
+}
+
+// claim claims the next chunk of the given range, if any.
+func (j *job) claim(r *chunkRange) (int, bool) {
+	if atomic.LoadInt64(&r.next) >= r.end {
+		return 0, false
+	}
+
+	i := atomic.AddInt64(&r.next, 1) - 1
+	if i >= r.end {
+		return 0, false
+	}
+
+	return int(i), true
+}
+
+// participate processes the chunks of the participant's own range,
+// then steals the remaining chunks from the other participants.
+func (j *job) participate(p int) {
+	for k := 0; k < len(j.ranges); k++ {
+		r := &j.ranges[(p+k)%len(j.ranges)]
+
+		for {
+			i, ok := j.claim(r)
+			if !ok {
+				break
+			}
+
+			j.f(i, i*j.size/j.n, (i+1)*j.size/j.n)
+			j.wg.Done()
+		}
+	}
+}
+
+// A ticket invites a worker to participate in a job,
+// or to stop if it holds no job.
+type ticket struct {
+	job *job
+	p   int
+}
+
+// The states of the worker pool.
+const (
+	idle    = iota // never started
+	running        // started
+	stopped        // explicitly shut down
+)
+
+// pool is the persistent worker pool shared by all parallel kernels.
+var pool struct {
+	sync.Mutex
+	state   int
+	workers int32       // the number of running workers
+	tickets chan ticket // the tickets awaiting a worker
+	wg      sync.WaitGroup
+}
+
+// worker runs the tickets it receives until it gets a stop ticket.
+func worker() {
+	for t := range pool.tickets {
+		if t.job == nil {
+			break
+		}
+
+		t.job.participate(t.p)
+	}
+
+	pool.wg.Done()
+}
+
+// resize sets the number of running workers. The pool must be locked.
+func resize(n int) {
+	if pool.tickets == nil {
+		pool.tickets = make(chan ticket, 4*runtime.NumCPU())
+	}
+
+	cur := int(atomic.LoadInt32(&pool.workers))
+
+	for ; cur < n; cur++ {
+		pool.wg.Add(1)
+		go worker()
+	}
+
+	for ; cur > n; cur-- {
+		pool.tickets <- ticket{}
+	}
+
+	atomic.StoreInt32(&pool.workers, int32(n))
+}
+
+// DefaultSize returns the number of workers the pool
+// is started with the first time it's needed.
+var DefaultSize = runtime.NumCPU
+
+// Start starts the worker pool with n workers,
+// or resizes it if it's already running.
+func Start(n int) {
+	pool.Lock()
+	defer pool.Unlock()
+
+	resize(n)
+	pool.state = running
+}
+
+// Resize sets the number of workers of the running worker pool.
+// It does nothing if the pool isn't running.
+func Resize(n int) {
+	pool.Lock()
+	defer pool.Unlock()
+
+	if pool.state != running {
+		return
+	}
+
+	resize(n)
+}
+
+// Shutdown stops the workers of the worker pool and waits for
+// them to exit. Parallel calls then run on their caller's
+// goroutine until the pool is started again with Start.
+func Shutdown() {
+	pool.Lock()
+	defer pool.Unlock()
+
+	if pool.state != running {
+		pool.state = stopped
+		return
+	}
+
+	resize(0)
+	pool.wg.Wait()
+
+	// drop the tickets of jobs no worker got to
+	for len(pool.tickets) > 0 {
+		<-pool.tickets
+	}
+
+	pool.state = stopped
+}
+
+// Size returns the number of running workers in the worker pool.
+func Size() int {
+	return int(atomic.LoadInt32(&pool.workers))
+}
+
+// Parallelize splits the interval [0, size) into nCPU shares
+// and concurrently calls f on each share, along with its number.
+// The shares are run by the calling goroutine and the pool's workers,
+// where idle participants steal the shares others have yet to run.
+// The pool is started with DefaultSize workers the first time
+// it's needed, unless it was explicitly started or shut down.
+func Parallelize(size, nCPU int, f func(i, min, max int)) {
+	if nCPU <= 1 {
+		f(0, 0, size)
+		return
+	}
+
+	if atomic.LoadInt32(&pool.workers) == 0 {
+		pool.Lock()
+		if pool.state == idle {
+			resize(DefaultSize())
+			pool.state = running
+		}
+		pool.Unlock()
+	}
+
+	participants := int(atomic.LoadInt32(&pool.workers)) + 1
+	if participants > nCPU {
+		participants = nCPU
+	}
+
+	j := &job{
+		size:   size,
+		n:      nCPU,
+		f:      f,
+		ranges: make([]chunkRange, participants),
+	}
+
+	for p := range j.ranges {
+		j.ranges[p].next = int64(p * nCPU / participants)
+		j.ranges[p].end = int64((p + 1) * nCPU / participants)
+	}
+
+	j.wg.Add(nCPU)
+
+	// invite the workers without blocking, as the
+	// caller can run all the shares by itself
+	for p := 1; p < participants; p++ {
+		select {
+		case pool.tickets <- ticket{job: j, p: p}:
+		default:
+		}
+	}
+
+	j.participate(0)
+	j.wg.Wait()
+}
@@ -0,0 +1,179 @@
+// Copyright © The Nune Author. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package nune
+
+// float is the set of floating-point types with dedicated kernels.
+type float interface {
+	float32 | float64
+}
+
+// A kernelOp identifies an operation that has a dedicated kernel
+// for contiguous buffers of float32 or float64 elements.
+type kernelOp int
+
+const (
+	opNone kernelOp = iota // no dedicated kernel
+
+	// elementwise operations
+	opAdd
+	opSub
+	opMul
+	opDiv
+
+	// pointwise operations
+	opAbs
+	opSqrt
+	opExp
+	opFloor
+	opCeil
+	opTrunc
+
+	// reductions
+	opSum
+	opMin
+	opMax
+)
+
+// zipKernel computes dst = dst op src with a dedicated kernel,
+// and returns whether or not one exists for the operation and type.
+func zipKernel[T Number](op kernelOp, dst, src []T) bool {
+	if op == opNone {
+		return false
+	}
+
+	switch dst := any(dst).(type) {
+	case []float32:
+		zipFloat32(op, dst, any(src).([]float32))
+		return true
+	case []float64:
+		zipFloat64(op, dst, any(src).([]float64))
+		return true
+	}
+
+	return false
+}
+
+// zipScalarKernel computes dst = dst op c with a dedicated kernel,
+// and returns whether or not one exists for the operation and type.
+func zipScalarKernel[T Number](op kernelOp, dst []T, c T) bool {
+	if op == opNone {
+		return false
+	}
+
+	switch dst := any(dst).(type) {
+	case []float32:
+		zipFloat32Scalar(op, dst, any(c).(float32))
+		return true
+	case []float64:
+		zipFloat64Scalar(op, dst, any(c).(float64))
+		return true
+	}
+
+	return false
+}
+
+// mapKernel applies the operation to the buffer with a dedicated kernel,
+// and returns whether or not one exists for the operation and type.
+func mapKernel[T Number](op kernelOp, buf []T) bool {
+	if op == opNone {
+		return false
+	}
+
+	switch buf := any(buf).(type) {
+	case []float32:
+		mapFloat32(op, buf)
+		return true
+	case []float64:
+		mapFloat64(op, buf)
+		return true
+	}
+
+	return false
+}
+
+// reduceKernel reduces the non-empty buffer with a dedicated kernel,
+// and returns whether or not one exists for the operation and type.
+func reduceKernel[T Number](op kernelOp, buf []T) (T, bool) {
+	switch buf := any(buf).(type) {
+	case []float32:
+		return any(reduceFloat32(op, buf)).(T), true
+	case []float64:
+		return any(reduceFloat64(op, buf)).(T), true
+	}
+
+	var zero T
+	return zero, false
+}
+
+// The kernels are instantiated by the non-generic functions below,
+// since the compiler only turns math's functions into instructions
+// in the generic functions instantiated by this package.
+
+func zipFloat32(op kernelOp, dst, src []float32)             { zipFloats(op, dst, src) }
+func zipFloat64(op kernelOp, dst, src []float64)             { zipFloats(op, dst, src) }
+func zipFloat32Scalar(op kernelOp, dst []float32, c float32) { zipFloatsScalar(op, dst, c) }
+func zipFloat64Scalar(op kernelOp, dst []float64, c float64) { zipFloatsScalar(op, dst, c) }
+func mapFloat32(op kernelOp, buf []float32)                  { mapFloats(op, buf) }
+func mapFloat64(op kernelOp, buf []float64)                  { mapFloats(op, buf) }
+func reduceFloat32(op kernelOp, buf []float32) float32       { return reduceFloats(op, buf) }
+func reduceFloat64(op kernelOp, buf []float64) float64       { return reduceFloats(op, buf) }
+
+// zipFloats computes dst = dst op src.
+func zipFloats[F float](op kernelOp, dst, src []F) {
+	switch op {
+	case opAdd:
+		addFloats(dst, src)
+	case opSub:
+		subFloats(dst, src)
+	case opMul:
+		mulFloats(dst, src)
+	case opDiv:
+		divFloats(dst, src)
+	}
+}
+
+// zipFloatsScalar computes dst = dst op c.
+func zipFloatsScalar[F float](op kernelOp, dst []F, c F) {
+	switch op {
+	case opAdd:
+		addFloatsScalar(dst, c)
+	case opSub:
+		subFloatsScalar(dst, c)
+	case opMul:
+		mulFloatsScalar(dst, c)
+	case opDiv:
+		divFloatsScalar(dst, c)
+	}
+}
+
+// mapFloats applies the pointwise operation to the buffer.
+func mapFloats[F float](op kernelOp, buf []F) {
+	switch op {
+	case opAbs:
+		absFloats(buf)
+	case opSqrt:
+		sqrtFloats(buf)
+	case opExp:
+		expFloats(buf)
+	case opFloor:
+		floorFloats(buf)
+	case opCeil:
+		ceilFloats(buf)
+	case opTrunc:
+		truncFloats(buf)
+	}
+}
+
+// reduceFloats reduces the non-empty buffer.
+func reduceFloats[F float](op kernelOp, buf []F) F {
+	switch op {
+	case opMin:
+		return minFloats(buf)
+	case opMax:
+		return maxFloats(buf)
+	default:
+		return sumFloats(buf)
+	}
+}
@@ -0,0 +1,144 @@
+// Copyright © The Nune Author. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build !(amd64 || arm64) || purego
+
+package nune
+
+import (
+	"math"
+)
+
+// addFloats stores the elementwise sum of dst and src in dst.
+func addFloats[F float](dst, src []F) {
+	src = src[:len(dst)]
+	for i := range dst {
+		dst[i] += src[i]
+	}
+}
+
+// addFloatsScalar stores the elementwise sum of dst and c in dst.
+func addFloatsScalar[F float](dst []F, c F) {
+	for i := range dst {
+		dst[i] += c
+	}
+}
+
+// subFloats stores the elementwise difference of dst and src in dst.
+func subFloats[F float](dst, src []F) {
+	src = src[:len(dst)]
+	for i := range dst {
+		dst[i] -= src[i]
+	}
+}
+
+// subFloatsScalar stores the elementwise difference of dst and c in dst.
+func subFloatsScalar[F float](dst []F, c F) {
+	for i := range dst {
+		dst[i] -= c
+	}
+}
+
+// mulFloats stores the elementwise product of dst and src in dst.
+func mulFloats[F float](dst, src []F) {
+	src = src[:len(dst)]
+	for i := range dst {
+		dst[i] *= src[i]
+	}
+}
+
+// mulFloatsScalar stores the elementwise product of dst and c in dst.
+func mulFloatsScalar[F float](dst []F, c F) {
+	for i := range dst {
+		dst[i] *= c
+	}
+}
+
+// divFloats stores the elementwise quotient of dst and src in dst.
+func divFloats[F float](dst, src []F) {
+	src = src[:len(dst)]
+	for i := range dst {
+		dst[i] /= src[i]
+	}
+}
+
+// divFloatsScalar stores the elementwise quotient of dst and c in dst.
+func divFloatsScalar[F float](dst []F, c F) {
+	for i := range dst {
+		dst[i] /= c
+	}
+}
+
+// absFloats replaces the buffer's elements by their absolute values.
+func absFloats[F float](buf []F) {
+	for i := range buf {
+		buf[i] = F(math.Abs(float64(buf[i])))
+	}
+}
+
+// sqrtFloats replaces the buffer's elements by their square roots.
+func sqrtFloats[F float](buf []F) {
+	for i := range buf {
+		buf[i] = F(math.Sqrt(float64(buf[i])))
+	}
+}
+
+// expFloats replaces the buffer's elements by their exponentials.
+func expFloats[F float](buf []F) {
+	for i := range buf {
+		buf[i] = F(math.Exp(float64(buf[i])))
+	}
+}
+
+// floorFloats replaces the buffer's elements by their floors.
+func floorFloats[F float](buf []F) {
+	for i := range buf {
+		buf[i] = F(math.Floor(float64(buf[i])))
+	}
+}
+
+// ceilFloats replaces the buffer's elements by their ceilings.
+func ceilFloats[F float](buf []F) {
+	for i := range buf {
+		buf[i] = F(math.Ceil(float64(buf[i])))
+	}
+}
+
+// truncFloats replaces the buffer's elements by their integer values.
+func truncFloats[F float](buf []F) {
+	for i := range buf {
+		buf[i] = F(math.Trunc(float64(buf[i])))
+	}
+}
+
+// sumFloats returns the sum of the buffer's elements.
+func sumFloats[F float](buf []F) F {
+	var sum F
+	for _, x := range buf {
+		sum += x
+	}
+	return sum
+}
+
+// minFloats returns the minimum of the non-empty buffer's elements.
+func minFloats[F float](buf []F) F {
+	m := buf[0]
+	for _, x := range buf[1:] {
+		if x < m {
+			m = x
+		}
+	}
+	return m
+}
+
+// maxFloats returns the maximum of the non-empty buffer's elements.
+func maxFloats[F float](buf []F) F {
+	m := buf[0]
+	for _, x := range buf[1:] {
+		if x > m {
+			m = x
+		}
+	}
+	return m
+}
@@ -0,0 +1,47 @@
+// Copyright © The Nune Author. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package nune_test
+
+import (
+	"testing"
+
+	"github.com/vorduin/nune"
+)
+
+func newTensor32() nune.Tensor[float32] {
+	return nune.Range[float32](0, 1e7, 1).WithEngine(engine)
+}
+
+func BenchmarkAddFloat32(b *testing.B) {
+	tensor := newTensor32()
+
+	benchmarkOp(b, func() {
+		tensor.Add(tensor)
+	})
+}
+
+func BenchmarkAddScalarFloat32(b *testing.B) {
+	tensor := newTensor32()
+
+	benchmarkOp(b, func() {
+		tensor.Add(1)
+	})
+}
+
+func BenchmarkSqrtFloat32(b *testing.B) {
+	tensor := newTensor32()
+
+	benchmarkOp(b, func() {
+		tensor.Sqrt()
+	})
+}
+
+func BenchmarkSumFloat32(b *testing.B) {
+	tensor := newTensor32()
+
+	benchmarkOp(b, func() {
+		tensor.Sum()
+	})
+}
@@ -0,0 +1,366 @@
+// Copyright © The Nune Author. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build (amd64 || arm64) && !purego
+
+package nune
+
+import (
+	"math"
+)
+
+// The kernels below are unrolled by 4 so that the compiler eliminates
+// the bounds checks and keeps independent operations in flight.
+// Each of them is kept small so that math's Abs, Sqrt, Floor, Ceil
+// and Trunc are inlined as single instructions on amd64 and arm64.
+
+// addFloats stores the elementwise sum of dst and src in dst.
+func addFloats[F float](dst, src []F) {
+	n := len(dst) &^ 3
+	src = src[:len(dst)]
+
+	for i := 0; i < n; i += 4 {
+		d, s := dst[i:i+4:i+4], src[i:i+4:i+4]
+		d[0] += s[0]
+		d[1] += s[1]
+		d[2] += s[2]
+		d[3] += s[3]
+	}
+	for i := n; i < len(dst); i++ {
+		dst[i] += src[i]
+	}
+}
+
+// addFloatsScalar stores the elementwise sum of dst and c in dst.
+func addFloatsScalar[F float](dst []F, c F) {
+	n := len(dst) &^ 3
+
+	for i := 0; i < n; i += 4 {
+		d := dst[i : i+4 : i+4]
+		d[0] += c
+		d[1] += c
+		d[2] += c
+		d[3] += c
+	}
+	for i := n; i < len(dst); i++ {
+		dst[i] += c
+	}
+}
+
+// subFloats stores the elementwise difference of dst and src in dst.
+func subFloats[F float](dst, src []F) {
+	n := len(dst) &^ 3
+	src = src[:len(dst)]
+
+	for i := 0; i < n; i += 4 {
+		d, s := dst[i:i+4:i+4], src[i:i+4:i+4]
+		d[0] -= s[0]
+		d[1] -= s[1]
+		d[2] -= s[2]
+		d[3] -= s[3]
+	}
+	for i := n; i < len(dst); i++ {
+		dst[i] -= src[i]
+	}
+}
+
+// subFloatsScalar stores the elementwise difference of dst and c in dst.
+func subFloatsScalar[F float](dst []F, c F) {
+	n := len(dst) &^ 3
+
+	for i := 0; i < n; i += 4 {
+		d := dst[i : i+4 : i+4]
+		d[0] -= c
+		d[1] -= c
+		d[2] -= c
+		d[3] -= c
+	}
+	for i := n; i < len(dst); i++ {
+		dst[i] -= c
+	}
+}
+
+// mulFloats stores the elementwise product of dst and src in dst.
+func mulFloats[F float](dst, src []F) {
+	n := len(dst) &^ 3
+	src = src[:len(dst)]
+
+	for i := 0; i < n; i += 4 {
+		d, s := dst[i:i+4:i+4], src[i:i+4:i+4]
+		d[0] *= s[0]
+		d[1] *= s[1]
+		d[2] *= s[2]
+		d[3] *= s[3]
+	}
+	for i := n; i < len(dst); i++ {
+		dst[i] *= src[i]
+	}
+}
+
+// mulFloatsScalar stores the elementwise product of dst and c in dst.
+func mulFloatsScalar[F float](dst []F, c F) {
+	n := len(dst) &^ 3
+
+	for i := 0; i < n; i += 4 {
+		d := dst[i : i+4 : i+4]
+		d[0] *= c
+		d[1] *= c
+		d[2] *= c
+		d[3] *= c
+	}
+	for i := n; i < len(dst); i++ {
+		dst[i] *= c
+	}
+}
+
+// divFloats stores the elementwise quotient of dst and src in dst.
+func divFloats[F float](dst, src []F) {
+	n := len(dst) &^ 3
+	src = src[:len(dst)]
+
+	for i := 0; i < n; i += 4 {
+		d, s := dst[i:i+4:i+4], src[i:i+4:i+4]
+		d[0] /= s[0]
+		d[1] /= s[1]
+		d[2] /= s[2]
+		d[3] /= s[3]
+	}
+	for i := n; i < len(dst); i++ {
+		dst[i] /= src[i]
+	}
+}
+
+// divFloatsScalar stores the elementwise quotient of dst and c in dst.
+func divFloatsScalar[F float](dst []F, c F) {
+	n := len(dst) &^ 3
+
+	for i := 0; i < n; i += 4 {
+		d := dst[i : i+4 : i+4]
+		d[0] /= c
+		d[1] /= c
+		d[2] /= c
+		d[3] /= c
+	}
+	for i := n; i < len(dst); i++ {
+		dst[i] /= c
+	}
+}
+
+// absFloats replaces the buffer's elements by their absolute values.
+func absFloats[F float](buf []F) {
+	n := len(buf) &^ 3
+
+	for i := 0; i < n; i += 4 {
+		b := buf[i : i+4 : i+4]
+		b[0] = F(math.Abs(float64(b[0])))
+		b[1] = F(math.Abs(float64(b[1])))
+		b[2] = F(math.Abs(float64(b[2])))
+		b[3] = F(math.Abs(float64(b[3])))
+	}
+	for i := n; i < len(buf); i++ {
+		buf[i] = F(math.Abs(float64(buf[i])))
+	}
+}
+
+// sqrtFloats replaces the buffer's elements by their square roots.
+func sqrtFloats[F float](buf []F) {
+	n := len(buf) &^ 3
+
+	for i := 0; i < n; i += 4 {
+		b := buf[i : i+4 : i+4]
+		b[0] = F(math.Sqrt(float64(b[0])))
+		b[1] = F(math.Sqrt(float64(b[1])))
+		b[2] = F(math.Sqrt(float64(b[2])))
+		b[3] = F(math.Sqrt(float64(b[3])))
+	}
+	for i := n; i < len(buf); i++ {
+		buf[i] = F(math.Sqrt(float64(buf[i])))
+	}
+}
+
+// expFloats replaces the buffer's elements by their exponentials.
+func expFloats[F float](buf []F) {
+	n := len(buf) &^ 3
+
+	for i := 0; i < n; i += 4 {
+		b := buf[i : i+4 : i+4]
+		b[0] = F(math.Exp(float64(b[0])))
+		b[1] = F(math.Exp(float64(b[1])))
+		b[2] = F(math.Exp(float64(b[2])))
+		b[3] = F(math.Exp(float64(b[3])))
+	}
+	for i := n; i < len(buf); i++ {
+		buf[i] = F(math.Exp(float64(buf[i])))
+	}
+}
+
+// floorFloats replaces the buffer's elements by their floors.
+func floorFloats[F float](buf []F) {
+	n := len(buf) &^ 3
+
+	for i := 0; i < n; i += 4 {
+		b := buf[i : i+4 : i+4]
+		b[0] = F(math.Floor(float64(b[0])))
+		b[1] = F(math.Floor(float64(b[1])))
+		b[2] = F(math.Floor(float64(b[2])))
+		b[3] = F(math.Floor(float64(b[3])))
+	}
+	for i := n; i < len(buf); i++ {
+		buf[i] = F(math.Floor(float64(buf[i])))
+	}
+}
+
+// ceilFloats replaces the buffer's elements by their ceilings.
+func ceilFloats[F float](buf []F) {
+	n := len(buf) &^ 3
+
+	for i := 0; i < n; i += 4 {
+		b := buf[i : i+4 : i+4]
+		b[0] = F(math.Ceil(float64(b[0])))
+		b[1] = F(math.Ceil(float64(b[1])))
+		b[2] = F(math.Ceil(float64(b[2])))
+		b[3] = F(math.Ceil(float64(b[3])))
+	}
+	for i := n; i < len(buf); i++ {
+		buf[i] = F(math.Ceil(float64(buf[i])))
+	}
+}
+
+// truncFloats replaces the buffer's elements by their integer values.
+func truncFloats[F float](buf []F) {
+	n := len(buf) &^ 3
+
+	for i := 0; i < n; i += 4 {
+		b := buf[i : i+4 : i+4]
+		b[0] = F(math.Trunc(float64(b[0])))
+		b[1] = F(math.Trunc(float64(b[1])))
+		b[2] = F(math.Trunc(float64(b[2])))
+		b[3] = F(math.Trunc(float64(b[3])))
+	}
+	for i := n; i < len(buf); i++ {
+		buf[i] = F(math.Trunc(float64(buf[i])))
+	}
+}
+
+// sumFloats returns the sum of the buffer's elements,
+// accumulated in four independent partial sums.
+func sumFloats[F float](buf []F) F {
+	n := len(buf) &^ 3
+
+	var s0, s1, s2, s3 F
+	for i := 0; i < n; i += 4 {
+		b := buf[i : i+4 : i+4]
+		s0 += b[0]
+		s1 += b[1]
+		s2 += b[2]
+		s3 += b[3]
+	}
+	for i := n; i < len(buf); i++ {
+		s0 += buf[i]
+	}
+
+	return (s0 + s1) + (s2 + s3)
+}
+
+// minFloats returns the minimum of the non-empty buffer's elements,
+// tracked in four independent partial results. As in the generic path,
+// a NaN is only propagated when it's the buffer's first element.
+func minFloats[F float](buf []F) F {
+	n := len(buf) &^ 3
+
+	m0 := buf[0]
+	if n == 0 {
+		for i := 1; i < len(buf); i++ {
+			if buf[i] < m0 {
+				m0 = buf[i]
+			}
+		}
+		return m0
+	}
+
+	m1, m2, m3 := buf[1], buf[2], buf[3]
+	for i := 4; i < n; i += 4 {
+		b := buf[i : i+4 : i+4]
+		if b[0] < m0 {
+			m0 = b[0]
+		}
+		if b[1] < m1 {
+			m1 = b[1]
+		}
+		if b[2] < m2 {
+			m2 = b[2]
+		}
+		if b[3] < m3 {
+			m3 = b[3]
+		}
+	}
+	for i := n; i < len(buf); i++ {
+		if buf[i] < m0 {
+			m0 = buf[i]
+		}
+	}
+
+	if m1 < m0 {
+		m0 = m1
+	}
+	if m2 < m0 {
+		m0 = m2
+	}
+	if m3 < m0 {
+		m0 = m3
+	}
+
+	return m0
+}
+
+// maxFloats returns the maximum of the non-empty buffer's elements,
+// tracked in four independent partial results. As in the generic path,
+// a NaN is only propagated when it's the buffer's first element.
+func maxFloats[F float](buf []F) F {
+	n := len(buf) &^ 3
+
+	m0 := buf[0]
+	if n == 0 {
+		for i := 1; i < len(buf); i++ {
+			if buf[i] > m0 {
+				m0 = buf[i]
+			}
+		}
+		return m0
+	}
+
+	m1, m2, m3 := buf[1], buf[2], buf[3]
+	for i := 4; i < n; i += 4 {
+		b := buf[i : i+4 : i+4]
+		if b[0] > m0 {
+			m0 = b[0]
+		}
+		if b[1] > m1 {
+			m1 = b[1]
+		}
+		if b[2] > m2 {
+			m2 = b[2]
+		}
+		if b[3] > m3 {
+			m3 = b[3]
+		}
+	}
+	for i := n; i < len(buf); i++ {
+		if buf[i] > m0 {
+			m0 = buf[i]
+		}
+	}
+
+	if m1 > m0 {
+		m0 = m1
+	}
+	if m2 > m0 {
+		m0 = m2
+	}
+	if m3 > m0 {
+		m0 = m3
+	}
+
+	return m0
+}
@@ -0,0 +1,395 @@
+// Copyright © The Nune Author. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package nune
+
+import (
+	"github.com/vorduin/slices"
+)
+
+// exprBlock is the number of elements an expression
+// is evaluated over at once, in buffers that fit in cache.
+const exprBlock = 2048
+
+// An exprNode is a node in an expression graph,
+// whose children are nodes that precede it.
+type exprNode[T Number] struct {
+	leaf  int             // the index of the node's leaf Tensor, or -1
+	apply func(Tensor[T]) // the pointwise operation applied to the child's values
+	zip   func(T, T) T    // the elementwise operation applied to the children's values
+	op    kernelOp        // the elementwise operation's dedicated kernel, if any
+	a, b  int             // the indices of the node's children
+}
+
+// An Expr is a lazily evaluated pointwise expression over Tensors.
+// The pointwise and elementwise operations called on an Expr are
+// recorded in a graph, which Eval fuses into a single pass over
+// the Tensors' elements, without any intermediate Tensor.
+// The leaf Tensors are only read when the expression is evaluated.
+type Expr[T Number] struct {
+	root   Tensor[T]     // the Tensor the expression started from
+	shape  []int         // the shape of the expression's result
+	leaves []Tensor[T]   // the Tensors the expression reads from
+	nodes  []exprNode[T] // the graph's nodes, each after its children
+	Err    error         // holds the corresponding error when recording an operation fails
+}
+
+// Lazy returns a new expression starting from the Tensor's elements.
+// The operations called on the expression are only computed once
+// it's evaluated with Eval.
+func (t Tensor[T]) Lazy() Expr[T] {
+	if t.Err != nil {
+		if t.env().Interactive {
+			panic(t.Err)
+		} else {
+			return Expr[T]{
+				root: t,
+				Err:  t.Err,
+			}
+		}
+	}
+
+	return Expr[T]{
+		root:   t,
+		shape:  slices.Clone(t.shape),
+		leaves: []Tensor[T]{t},
+		nodes:  []exprNode[T]{{leaf: 0}},
+	}
+}
+
+// push returns a copy of the expression with the given node appended,
+// so that the expressions built from a common one don't share nodes.
+func (e Expr[T]) push(n exprNode[T]) Expr[T] {
+	nodes := make([]exprNode[T], len(e.nodes), len(e.nodes)+1)
+	copy(nodes, e.nodes)
+	e.nodes = append(nodes, n)
+
+	return e
+}
+
+// apply records a pointwise operation, defined by a Tensor method
+// that computes it in place on the Tensor it's called on.
+func (e Expr[T]) apply(f func(Tensor[T]) Tensor[T]) Expr[T] {
+	if e.Err != nil {
+		return e
+	}
+
+	return e.push(exprNode[T]{
+		leaf: -1,
+		apply: func(t Tensor[T]) {
+			f(t)
+		},
+		a: len(e.nodes) - 1,
+	})
+}
+
+// Map records a pointwise operation over the elements of the expression.
+func (e Expr[T]) Map(f func(T) T) Expr[T] {
+	return e.apply(func(t Tensor[T]) Tensor[T] {
+		return t.Map(f)
+	})
+}
+
+// Zip records an elementwise operation between other and the expression,
+// where other can be another expression, whose graph is merged into
+// this one, or any value that can be zipped with a Tensor.
+func (e Expr[T]) Zip(other any, f func(T, T) T) Expr[T] {
+	return e.zip(other, opNone, f)
+}
+
+// zip records an elementwise operation between other and the expression,
+// using the operation's dedicated kernel where possible.
+func (e Expr[T]) zip(other any, op kernelOp, f func(T, T) T) Expr[T] {
+	if e.Err != nil {
+		return e
+	}
+
+	var o Expr[T]
+	switch other := other.(type) {
+	case Expr[T]:
+		o = other
+	case Tensor[T]:
+		o = other.WithEngine(e.root.eng).Lazy()
+	default:
+		o = From[T](other).WithEngine(e.root.eng).Lazy()
+	}
+
+	if o.Err != nil {
+		if e.root.env().Interactive {
+			panic(o.Err)
+		} else {
+			e.Err = o.Err
+			return e
+		}
+	}
+
+	shape, err := broadcastShapes(e.shape, o.shape)
+	if err != nil {
+		err = newOpError("Zip", err, -1, e.shape, o.shape)
+		if e.root.env().Interactive {
+			panic(err)
+		} else {
+			e.Err = err
+			return e
+		}
+	}
+
+	// merge the other graph, shifting its indices past this one's
+	leaves := make([]Tensor[T], 0, len(e.leaves)+len(o.leaves))
+	leaves = append(append(leaves, e.leaves...), o.leaves...)
+
+	nodes := make([]exprNode[T], 0, len(e.nodes)+len(o.nodes)+1)
+	nodes = append(nodes, e.nodes...)
+	for _, n := range o.nodes {
+		if n.leaf >= 0 {
+			n.leaf += len(e.leaves)
+		}
+		n.a += len(e.nodes)
+		n.b += len(e.nodes)
+		nodes = append(nodes, n)
+	}
+
+	nodes = append(nodes, exprNode[T]{
+		leaf: -1,
+		zip:  f,
+		op:   op,
+		a:    len(e.nodes) - 1,
+		b:    len(nodes) - 1,
+	})
+
+	e.shape = shape
+	e.leaves = leaves
+	e.nodes = nodes
+
+	return e
+}
+
+// Add records an elementwise addition between other and the expression.
+func (e Expr[T]) Add(other any) Expr[T] {
+	return e.zip(other, opAdd, func(x, y T) T {
+		return x + y
+	})
+}
+
+// Sub records an elementwise subtraction between other and the expression.
+func (e Expr[T]) Sub(other any) Expr[T] {
+	return e.zip(other, opSub, func(x, y T) T {
+		return x - y
+	})
+}
+
+// Mul records an elementwise multiplication between other and the expression.
+func (e Expr[T]) Mul(other any) Expr[T] {
+	return e.zip(other, opMul, func(x, y T) T {
+		return x * y
+	})
+}
+
+// Div records an elementwise division between other and the expression.
+func (e Expr[T]) Div(other any) Expr[T] {
+	return e.zip(other, opDiv, func(x, y T) T {
+		return x / y
+	})
+}
+
+// Shape returns the shape of the expression's result.
+func (e Expr[T]) Shape() []int {
+	return e.shape
+}
+
+// Eval computes the expression in a single pass over its Tensors' elements.
+// As with the eager operations, the results are stored in the Tensor the
+// expression started from, unless they were broadcast to a larger shape,
+// in which case they are stored in a new Tensor.
+func (e Expr[T]) Eval() Tensor[T] {
+	if e.Err != nil {
+		if e.root.env().Interactive {
+			panic(e.Err)
+		} else {
+			e.root.Err = e.Err
+			return e.root
+		}
+	}
+
+	out := e.root
+	if !slices.Equal(e.shape, out.shape) {
+		out = Zeros[T](e.shape...).WithEngine(out.eng)
+	} else if out, ok := out.writable("Eval"); !ok {
+		return out
+	}
+
+	leaves := make([]Tensor[T], len(e.leaves))
+	for i, l := range e.leaves {
+		// the leaves viewing the output's buffer with another layout
+		// would be read after being written to, so they're copied
+		if i > 0 && len(l.data) > 0 && &l.data[0] == &out.data[0] &&
+			(l.offset != out.offset || !slices.Equal(l.shape, out.shape) || !slices.Equal(l.stride, out.stride)) {
+			l = l.Clone()
+		}
+		leaves[i] = l.Expand(e.shape...)
+	}
+
+	handleExpr(out, leaves, e.nodes, out.env().configCPU(out.Numel()*len(e.nodes)))
+
+	return out
+}
+
+// handleExpr evaluates the expression graph over its leaves, all of the
+// same shape, and stores the results in the output Tensor. The elements
+// are walked over in lockstep in blocks, each block flowing through the
+// whole graph in buffers before the results are written.
+func handleExpr[T Number](out Tensor[T], leaves []Tensor[T], nodes []exprNode[T], nCPU int) {
+	offsets := make([]int, len(leaves)+1)
+	strides := make([][]int, len(leaves)+1)
+
+	offsets[0], strides[0] = out.offset, out.stride
+	for i, l := range leaves {
+		offsets[i+1], strides[i+1] = l.offset, l.stride
+	}
+
+	shape, strides := coalesce(out.shape, strides...)
+
+	// the number of nodes reading each node's values,
+	// as a node read only once can be computed in place
+	uses := make([]int, len(nodes))
+	for _, node := range nodes {
+		if node.leaf < 0 {
+			uses[node.a]++
+			if node.apply == nil {
+				uses[node.b]++
+			}
+		}
+	}
+
+	parallelize(out.Numel(), nCPU, func(_, min, max int) {
+		walkers := make([]*walker, len(strides))
+		for k := range walkers {
+			walkers[k] = newWalker(shape, strides[k], offsets[k], min)
+		}
+
+		// the values of each node over the current block, which are
+		// either held in a buffer owned by the evaluation, read directly
+		// from a contiguous leaf's data buffer, or constant along the block
+		bufs := make([][]T, len(nodes))
+		vals := make([][]T, len(nodes))
+		owned := make([]bool, len(nodes))
+		isConst := make([]bool, len(nodes))
+		consts := make([]T, len(nodes))
+		for k := range bufs {
+			bufs[k] = slices.WithLen[T](exprBlock)
+		}
+
+		// the pointwise operations are applied to
+		// the buffers through a serial Tensor view
+		view := Tensor[T]{
+			shape:  []int{0},
+			stride: []int{1},
+			eng:    &Engine{NumCPU: 1},
+		}
+
+		pos := slices.WithLen[int](len(walkers))
+		step := slices.WithLen[int](len(walkers))
+
+		// operand returns the values of the given node in
+		// a buffer the node k can compute its values in
+		operand := func(a, k, n int) []T {
+			switch {
+			case isConst[a]:
+				buf := bufs[k][:n]
+				for j := range buf {
+					buf[j] = consts[a]
+				}
+				return buf
+			case owned[a] && uses[a] == 1:
+				return vals[a]
+			default:
+				buf := bufs[k][:n]
+				copy(buf, vals[a])
+				return buf
+			}
+		}
+
+		for i := min; i < max; {
+			var l int
+			for k, w := range walkers {
+				pos[k], step[k], l = w.run(max - i)
+			}
+			i += l
+
+			for start := 0; start < l; start += exprBlock {
+				n := l - start
+				if n > exprBlock {
+					n = exprBlock
+				}
+
+				for k, node := range nodes {
+					isConst[k] = false
+
+					if node.leaf >= 0 {
+						src := leaves[node.leaf].data
+						p, s := pos[node.leaf+1]+start*step[node.leaf+1], step[node.leaf+1]
+
+						switch s {
+						case 0:
+							consts[k], isConst[k] = src[p], true
+						case 1:
+							vals[k], owned[k] = src[p:p+n], false
+						default:
+							buf := bufs[k][:n]
+							for j := range buf {
+								buf[j] = src[p]
+								p += s
+							}
+							vals[k], owned[k] = buf, true
+						}
+
+						continue
+					}
+
+					buf := operand(node.a, k, n)
+
+					switch {
+					case node.apply != nil:
+						view.data, view.shape[0] = buf, n
+						node.apply(view)
+					case isConst[node.b]:
+						c := consts[node.b]
+						if !zipScalarKernel(node.op, buf, c) {
+							for j := range buf {
+								buf[j] = node.zip(buf[j], c)
+							}
+						}
+					default:
+						rhs := vals[node.b]
+						if !zipKernel(node.op, buf, rhs) {
+							for j := range buf {
+								buf[j] = node.zip(buf[j], rhs[j])
+							}
+						}
+					}
+
+					vals[k], owned[k] = buf, true
+				}
+
+				last := len(nodes) - 1
+				p, s := pos[0]+start*step[0], step[0]
+
+				switch {
+				case isConst[last]:
+					for j := 0; j < n; j++ {
+						out.data[p] = consts[last]
+						p += s
+					}
+				case s == 1:
+					copy(out.data[p:p+n], vals[last])
+				default:
+					for _, x := range vals[last] {
+						out.data[p] = x
+						p += s
+					}
+				}
+			}
+		}
+	})
+}
@@ -0,0 +1,320 @@
+// Copyright © The Nune Author. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package nune
+
+// Abs computes the absolute value of each element in the expression.
+func (e Expr[T]) Abs() Expr[T] {
+	return e.apply(Tensor[T].Abs)
+}
+
+// Acos computes the arccosine, in radians, of each element in the expression.
+func (e Expr[T]) Acos() Expr[T] {
+	return e.apply(Tensor[T].Acos)
+}
+
+// Acosh computes the inverse hyperbolic cosine of each element in the expression.
+func (e Expr[T]) Acosh() Expr[T] {
+	return e.apply(Tensor[T].Acosh)
+}
+
+// Asin computes the arcsine, in radians, of each element in the expression.
+func (e Expr[T]) Asin() Expr[T] {
+	return e.apply(Tensor[T].Asin)
+}
+
+// Asinh computes the inverse hyperbolic sine of each element in the expression.
+func (e Expr[T]) Asinh() Expr[T] {
+	return e.apply(Tensor[T].Asinh)
+}
+
+// Atan computes the arctangent, in radians, of each element in the expression.
+func (e Expr[T]) Atan() Expr[T] {
+	return e.apply(Tensor[T].Atan)
+}
+
+// Atan2 computes the arc tangent of y/x, where x is each element in the expression,
+// using the signs of the two to determine the quadrant of the resulting value.
+func (e Expr[T]) Atan2(y float64) Expr[T] {
+	return e.apply(func(t Tensor[T]) Tensor[T] {
+		return t.Atan2(y)
+	})
+}
+
+// Atanh computes the inverse hyperbolic tangent of each element in the expression.
+func (e Expr[T]) Atanh() Expr[T] {
+	return e.apply(Tensor[T].Atanh)
+}
+
+// Cbrt computes the cubic root of each element in the expression.
+func (e Expr[T]) Cbrt() Expr[T] {
+	return e.apply(Tensor[T].Cbrt)
+}
+
+// Ceil computes the least integer value great than or equal to x,
+// where x is each element in the expression.
+func (e Expr[T]) Ceil() Expr[T] {
+	return e.apply(Tensor[T].Ceil)
+}
+
+// Copysign computes a value with the magnitude of x and
+// the sign of y, where x is each element in the expression.
+func (e Expr[T]) Copysign(y float64) Expr[T] {
+	return e.apply(func(t Tensor[T]) Tensor[T] {
+		return t.Copysign(y)
+	})
+}
+
+// Cos computes the cosine of each radian element of the expression.
+func (e Expr[T]) Cos() Expr[T] {
+	return e.apply(Tensor[T].Cos)
+}
+
+// Cosh computes the hyperbolic cosine of each element in the expression.
+func (e Expr[T]) Cosh() Expr[T] {
+	return e.apply(Tensor[T].Cosh)
+}
+
+// Dim computes the maximum of x-y or 0, where x is each
+// element in the expression.
+func (e Expr[T]) Dim(y float64) Expr[T] {
+	return e.apply(func(t Tensor[T]) Tensor[T] {
+		return t.Dim(y)
+	})
+}
+
+// Erf computes the error function of each element in the expression.
+func (e Expr[T]) Erf() Expr[T] {
+	return e.apply(Tensor[T].Erf)
+}
+
+// Erfc computes the complementary error function of each
+// element in the expression.
+func (e Expr[T]) Erfc() Expr[T] {
+	return e.apply(Tensor[T].Erfc)
+}
+
+// Erfcinv computes the inverse complementary error function
+// for each element in the expression.
+func (e Expr[T]) Erfcinv() Expr[T] {
+	return e.apply(Tensor[T].Erfcinv)
+}
+
+// Erfinv computes the inverse error function for each
+// element in the expression.
+func (e Expr[T]) Erfinv() Expr[T] {
+	return e.apply(Tensor[T].Erfinv)
+}
+
+// Exp computes the base-e exponential of each element in the expression.
+func (e Expr[T]) Exp() Expr[T] {
+	return e.apply(Tensor[T].Exp)
+}
+
+// Exp2 computes the base-2 exponential of each element in the expression.
+func (e Expr[T]) Exp2() Expr[T] {
+	return e.apply(Tensor[T].Exp2)
+}
+
+// Expm1 computes the base-e exponential of each element in the expression minus 1.
+// It is more accurate than exp(x) - 1 when the elements are near zero.
+func (e Expr[T]) Expm1() Expr[T] {
+	return e.apply(Tensor[T].Expm1)
+}
+
+// FMA computes x * y + z, where x is each element in the expression,
+// with only one rounding.
+// (That is, FMA returns the fused multiply-add of x, y, and z.)
+func (e Expr[T]) FMA(y, z float64) Expr[T] {
+	return e.apply(func(t Tensor[T]) Tensor[T] {
+		return t.FMA(y, z)
+	})
+}
+
+// Floor computes the greatest integer value less than or equal to
+// each element in the expression.
+func (e Expr[T]) Floor() Expr[T] {
+	return e.apply(Tensor[T].Floor)
+}
+
+// Gamma computes the Gamma function of each element in the expression.
+func (e Expr[T]) Gamma() Expr[T] {
+	return e.apply(Tensor[T].Gamma)
+}
+
+// Ilogb computes the binary exponent of each element in the expression
+// as an integer.
+func (e Expr[T]) Ilogb() Expr[T] {
+	return e.apply(Tensor[T].Ilogb)
+}
+
+// Inf computes positive infinity if x >= 0, negative infinity if x < 0,
+// where x is each element in the expression.
+func (e Expr[T]) Inf() Expr[T] {
+	return e.apply(Tensor[T].Inf)
+}
+
+// J0 computes the order-zero Bessel function of the first kind
+// for each element in the expression.
+func (e Expr[T]) J0() Expr[T] {
+	return e.apply(Tensor[T].J0)
+}
+
+// J1 computes the order-one Bessel function of the first kind
+// for each element in the expression.
+func (e Expr[T]) J1() Expr[T] {
+	return e.apply(Tensor[T].J1)
+}
+
+// Jn computes the order-n Bessel function of the first kind
+// for each element in the expression.
+func (e Expr[T]) Jn(n int) Expr[T] {
+	return e.apply(func(t Tensor[T]) Tensor[T] {
+		return t.Jn(n)
+	})
+}
+
+// Log computes the natural logarithm for each element in the expression.
+func (e Expr[T]) Log() Expr[T] {
+	return e.apply(Tensor[T].Log)
+}
+
+// Log10 computes the decimal logarithm for each element in the expression.
+func (e Expr[T]) Log10() Expr[T] {
+	return e.apply(Tensor[T].Log10)
+}
+
+// Log1p computes the natural logarithm of 1 plus its argument x,
+// where x is each element in the expression. It is more accurate
+// than log(1 + x) when x is near zero.
+func (e Expr[T]) Log1p() Expr[T] {
+	return e.apply(Tensor[T].Log1p)
+}
+
+// Log2 computes the binary logarithm of each element in the expression.
+func (e Expr[T]) Log2() Expr[T] {
+	return e.apply(Tensor[T].Log2)
+}
+
+// Logb computes the binary exponent of each element in the expression.
+func (e Expr[T]) Logb() Expr[T] {
+	return e.apply(Tensor[T].Logb)
+}
+
+// Mod computes the floating-point remainder of x/y, where x is each element
+// in the expression. The magnitude of the result is less than y and
+// its sign agrees with that of x.
+func (e Expr[T]) Mod(y float64) Expr[T] {
+	return e.apply(func(t Tensor[T]) Tensor[T] {
+		return t.Mod(y)
+	})
+}
+
+// NaN computes an IEEE 754 “not-a-number” value for each element
+// in the expression. Using this function is discouraged.
+func (e Expr[T]) NaN() Expr[T] {
+	return e.apply(Tensor[T].NaN)
+}
+
+// Nextafter computes the next representable float64 value after x towards y,
+// where x is each element in the expression.
+func (e Expr[T]) Nextafter(y float64) Expr[T] {
+	return e.apply(func(t Tensor[T]) Tensor[T] {
+		return t.Nextafter(y)
+	})
+}
+
+// Nextafter32 computes the next representable float32 value after x towards y,
+// where x is each element in the expression.
+func (e Expr[T]) Nextafter32(y float32) Expr[T] {
+	return e.apply(func(t Tensor[T]) Tensor[T] {
+		return t.Nextafter32(y)
+	})
+}
+
+// Pow computes the base-x exponential of y, where x is each
+// element in the expression.
+func (e Expr[T]) Pow(y float64) Expr[T] {
+	return e.apply(func(t Tensor[T]) Tensor[T] {
+		return t.Pow(y)
+	})
+}
+
+// Pow10 computes the base-10 exponential of n, for each element
+// in the expression.
+func (e Expr[T]) Pow10(n int) Expr[T] {
+	return e.apply(func(t Tensor[T]) Tensor[T] {
+		return t.Pow10(n)
+	})
+}
+
+// Remainder computes the IEEE 754 floating-point remainder of x/y,
+// where x is each element in the expression.
+func (e Expr[T]) Remainder(y float64) Expr[T] {
+	return e.apply(func(t Tensor[T]) Tensor[T] {
+		return t.Remainder(y)
+	})
+}
+
+// Round computes the nearest integer, rounding half away from zero,
+// for each element in the expression.
+func (e Expr[T]) Round() Expr[T] {
+	return e.apply(Tensor[T].Round)
+}
+
+// Round to even computes the nearest integer, rounding ties to even,
+// for each element in the expression.
+func (e Expr[T]) RoundToEven() Expr[T] {
+	return e.apply(Tensor[T].RoundToEven)
+}
+
+// Sin computes the sine of each radian element of the expression.
+func (e Expr[T]) Sin() Expr[T] {
+	return e.apply(Tensor[T].Sin)
+}
+
+// Sinh computes the hyperbolic sine of each element in the expression.
+func (e Expr[T]) Sinh() Expr[T] {
+	return e.apply(Tensor[T].Sinh)
+}
+
+// Sqrt computes the square root of each element in the expression.
+func (e Expr[T]) Sqrt() Expr[T] {
+	return e.apply(Tensor[T].Sqrt)
+}
+
+// Tan computes the tangent of each radian element of the expression.
+func (e Expr[T]) Tan() Expr[T] {
+	return e.apply(Tensor[T].Tan)
+}
+
+// Tanh computes the hyperbolic tangent of each radian element of the expression.
+func (e Expr[T]) Tanh() Expr[T] {
+	return e.apply(Tensor[T].Tanh)
+}
+
+// Trunc computes the integer value of each element in the expression.
+func (e Expr[T]) Trunc() Expr[T] {
+	return e.apply(Tensor[T].Trunc)
+}
+
+// Y0 computes the order-zero Bessel function of the second kind
+// of each element of the expression.
+func (e Expr[T]) Y0() Expr[T] {
+	return e.apply(Tensor[T].Y0)
+}
+
+// Y1 computes the order-one Bessel function of the second kind
+// of each element in the expression.
+func (e Expr[T]) Y1() Expr[T] {
+	return e.apply(Tensor[T].Y1)
+}
+
+// Yn computes the order-n Bessel function of the second kind
+// of each element in the expression.
+func (e Expr[T]) Yn(n int) Expr[T] {
+	return e.apply(func(t Tensor[T]) Tensor[T] {
+		return t.Yn(n)
+	})
+}
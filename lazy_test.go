@@ -0,0 +1,41 @@
+// Copyright © The Nune Author. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package nune_test
+
+import (
+	"testing"
+)
+
+func BenchmarkSigmoidEager(b *testing.B) {
+	tensor := newTensor()
+
+	benchmarkOp(b, func() {
+		tensor.Copysign(-1).Exp().Add(1).Pow(-1)
+	})
+}
+
+func BenchmarkSigmoidLazy(b *testing.B) {
+	tensor := newTensor()
+
+	benchmarkOp(b, func() {
+		tensor.Lazy().Copysign(-1).Exp().Add(1).Pow(-1).Eval()
+	})
+}
+
+func BenchmarkAffineEager(b *testing.B) {
+	tensor := newTensor()
+
+	benchmarkOp(b, func() {
+		tensor.Mul(0.5).Add(1).Abs().Sqrt()
+	})
+}
+
+func BenchmarkAffineLazy(b *testing.B) {
+	tensor := newTensor()
+
+	benchmarkOp(b, func() {
+		tensor.Lazy().Mul(0.5).Add(1).Abs().Sqrt().Eval()
+	})
+}
@@ -0,0 +1,127 @@
+// Copyright © The Nune Author. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package linalg
+
+import (
+	"math"
+	"sort"
+
+	"github.com/vorduin/nune"
+)
+
+// jacobiEigen computes the eigenvalues and eigenvectors of a symmetric
+// matrix in place using the cyclic Jacobi eigenvalue algorithm.
+// It returns the eigenvalues, the matrix whose columns are
+// the corresponding eigenvectors, and whether or not it converged.
+func jacobiEigen(a matrix) ([]float64, matrix, bool) {
+	n := a.r
+	v := identity(n)
+
+	// the Frobenius norm is invariant under the rotations
+	var norm float64
+	for _, x := range a.data {
+		norm = math.Hypot(norm, x)
+	}
+
+	converged := false
+	for sweep := 0; sweep < maxSweeps && !converged; sweep++ {
+		converged = true
+
+		for p := 0; p < n-1; p++ {
+			for q := p + 1; q < n; q++ {
+				apq := a.at(p, q)
+				if math.Abs(apq) <= eps*math.Sqrt(math.Abs(a.at(p, p)*a.at(q, q))) || math.Abs(apq) <= eps*eps*norm {
+					continue
+				}
+				converged = false
+
+				// the rotation annihilating a[p][q]
+				theta := (a.at(q, q) - a.at(p, p)) / (2 * apq)
+				t := math.Copysign(1, theta) / (math.Abs(theta) + math.Hypot(theta, 1))
+				c := 1 / math.Hypot(t, 1)
+				s := t * c
+
+				for k := 0; k < n; k++ {
+					akp, akq := a.at(k, p), a.at(k, q)
+					a.set(k, p, c*akp-s*akq)
+					a.set(k, q, s*akp+c*akq)
+				}
+
+				for k := 0; k < n; k++ {
+					apk, aqk := a.at(p, k), a.at(q, k)
+					a.set(p, k, c*apk-s*aqk)
+					a.set(q, k, s*apk+c*aqk)
+				}
+
+				for k := 0; k < n; k++ {
+					vkp, vkq := v.at(k, p), v.at(k, q)
+					v.set(k, p, c*vkp-s*vkq)
+					v.set(k, q, s*vkp+c*vkq)
+				}
+			}
+		}
+	}
+
+	w := make([]float64, n)
+	for i := range w {
+		w[i] = a.at(i, i)
+	}
+
+	return w, v, converged
+}
+
+// sortColumns sorts the values and the corresponding columns of
+// the matrix, in ascending order, or descending order if desc is true.
+func sortColumns(values []float64, m matrix, desc bool) ([]float64, matrix) {
+	idx := make([]int, len(values))
+	for i := range idx {
+		idx[i] = i
+	}
+
+	sort.SliceStable(idx, func(i, j int) bool {
+		if desc {
+			return values[idx[i]] > values[idx[j]]
+		}
+		return values[idx[i]] < values[idx[j]]
+	})
+
+	sorted := make([]float64, len(values))
+	sm := newMatrix(m.r, m.c)
+	for j, k := range idx {
+		sorted[j] = values[k]
+		for i := 0; i < m.r; i++ {
+			sm.set(i, j, m.at(i, k))
+		}
+	}
+
+	return sorted, sm
+}
+
+// Eigh returns the eigenvalues, in ascending order, and the
+// corresponding eigenvectors, as the columns of v, of the
+// symmetric matrix a. Only the lower triangle of a is read.
+func Eigh[T Float](a nune.Tensor[T]) (w, v nune.Tensor[T]) {
+	e := a.Engine()
+
+	m, err := toSquareMatrix(a)
+	if err != nil {
+		return fail[T](e, err), fail[T](e, err)
+	}
+
+	for i := 0; i < m.r; i++ {
+		for j := i + 1; j < m.c; j++ {
+			m.set(i, j, m.at(j, i))
+		}
+	}
+
+	values, vectors, ok := jacobiEigen(m)
+	if !ok {
+		return fail[T](e, ErrNoConvergence), fail[T](e, ErrNoConvergence)
+	}
+
+	values, vectors = sortColumns(values, vectors, false)
+
+	return fromVector[T](e, values), fromMatrix[T](e, vectors)
+}
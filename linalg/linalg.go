@@ -0,0 +1,192 @@
+// Copyright © The Nune Author. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package linalg implements dense linear algebra routines,
+// such as matrix decompositions and solvers, over
+// rank 2 floating point Nune Tensors.
+package linalg
+
+import (
+	"errors"
+	"math"
+
+	"github.com/vorduin/nune"
+)
+
+// Float is the set of all floating point types and their supersets.
+type Float interface {
+	~float32 | ~float64
+}
+
+// List of errors.
+var (
+	// ErrNotSquare occurs when a routine that requires
+	// a square matrix receives a non-square one.
+	ErrNotSquare = errors.New("linalg: matrix is not square")
+
+	// ErrSingular occurs when a matrix that must be
+	// invertible turns out to be singular.
+	ErrSingular = errors.New("linalg: matrix is singular")
+
+	// ErrNotPositiveDefinite occurs when the Cholesky decomposition
+	// receives a matrix that is not symmetric positive definite.
+	ErrNotPositiveDefinite = errors.New("linalg: matrix is not positive definite")
+
+	// ErrNoConvergence occurs when an iterative algorithm
+	// fails to converge within its maximum number of iterations.
+	ErrNoConvergence = errors.New("linalg: algorithm did not converge")
+)
+
+// maxSweeps is the maximum number of sweeps
+// performed by the Jacobi algorithms.
+const maxSweeps = 100
+
+// eps is the machine epsilon of float64.
+var eps = math.Nextafter(1, 2) - 1
+
+// A matrix is a dense, row-major float64 matrix the routines work on,
+// regardless of the Tensors' underlying type, to limit rounding errors.
+type matrix struct {
+	data []float64
+	r, c int
+}
+
+// newMatrix returns a zeroed matrix with r rows and c columns.
+func newMatrix(r, c int) matrix {
+	return matrix{
+		data: make([]float64, r*c),
+		r:    r,
+		c:    c,
+	}
+}
+
+// identity returns the n by n identity matrix.
+func identity(n int) matrix {
+	m := newMatrix(n, n)
+	for i := 0; i < n; i++ {
+		m.set(i, i, 1)
+	}
+	return m
+}
+
+// at returns the element at row i and column j.
+func (m matrix) at(i, j int) float64 {
+	return m.data[i*m.c+j]
+}
+
+// set sets the element at row i and column j.
+func (m matrix) set(i, j int, x float64) {
+	m.data[i*m.c+j] = x
+}
+
+// clone returns a copy of the matrix.
+func (m matrix) clone() matrix {
+	c := newMatrix(m.r, m.c)
+	copy(c.data, m.data)
+	return c
+}
+
+// transpose returns the transpose of the matrix.
+func (m matrix) transpose() matrix {
+	t := newMatrix(m.c, m.r)
+	for i := 0; i < m.r; i++ {
+		for j := 0; j < m.c; j++ {
+			t.set(j, i, m.at(i, j))
+		}
+	}
+	return t
+}
+
+// mul returns the matrix product of m and o.
+func (m matrix) mul(o matrix) matrix {
+	p := newMatrix(m.r, o.c)
+	for i := 0; i < m.r; i++ {
+		for k := 0; k < m.c; k++ {
+			a := m.at(i, k)
+			for j := 0; j < o.c; j++ {
+				p.data[i*p.c+j] += a * o.at(k, j)
+			}
+		}
+	}
+	return p
+}
+
+// toMatrix converts a rank 2 Tensor to a matrix.
+func toMatrix[T Float](t nune.Tensor[T]) (matrix, error) {
+	if t.Err != nil {
+		return matrix{}, t.Err
+	}
+
+	if t.Rank() != 2 {
+		return matrix{}, nune.ErrBadShape
+	}
+
+	return matrix{
+		data: nune.Cast[float64](t).Ravel(),
+		r:    t.Size(0),
+		c:    t.Size(1),
+	}, nil
+}
+
+// toSquareMatrix converts a rank 2 Tensor to a square matrix.
+func toSquareMatrix[T Float](t nune.Tensor[T]) (matrix, error) {
+	m, err := toMatrix(t)
+	if err == nil && m.r != m.c {
+		err = ErrNotSquare
+	}
+
+	return m, err
+}
+
+// toRHS converts a rank 1 or rank 2 Tensor to the
+// right-hand side matrix of a system with n equations.
+func toRHS[T Float](t nune.Tensor[T], n int) (matrix, error) {
+	if t.Err != nil {
+		return matrix{}, t.Err
+	}
+
+	if t.Rank() == 1 {
+		t = t.Unsqueeze(1)
+	}
+
+	m, err := toMatrix(t)
+	if err == nil && m.r != n {
+		err = nune.ErrShapeMismatch
+	}
+
+	return m, err
+}
+
+// fromMatrix converts a matrix to a rank 2 Tensor bound to the given Engine.
+func fromMatrix[T Float](e *nune.Engine, m matrix) nune.Tensor[T] {
+	return nune.Cast[T](nune.FromBuffer(m.data).Reshape(m.r, m.c)).WithEngine(e)
+}
+
+// fromVector converts a vector to a rank 1 Tensor bound to the given Engine.
+func fromVector[T Float](e *nune.Engine, v []float64) nune.Tensor[T] {
+	return nune.Cast[T](nune.FromBuffer(v)).WithEngine(e)
+}
+
+// fromScalar converts a scalar to a rank 0 Tensor bound to the given Engine.
+func fromScalar[T Float](e *nune.Engine, x float64) nune.Tensor[T] {
+	return nune.From[T](x).WithEngine(e)
+}
+
+// fail returns a Tensor holding the given error and bound to the given
+// Engine, or panics if the Engine's environment is interactive.
+// A nil Engine stands for Nune's global defaults.
+func fail[T Float](e *nune.Engine, err error) nune.Tensor[T] {
+	interactive := nune.EnvConfig.Interactive
+	if e != nil {
+		interactive = e.Interactive
+	}
+
+	if interactive {
+		panic(err)
+	}
+
+	return nune.Tensor[T]{
+		Err: err,
+	}.WithEngine(e)
+}
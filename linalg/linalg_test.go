@@ -0,0 +1,142 @@
+// Copyright © The Nune Author. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package linalg_test
+
+import (
+	"errors"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/vorduin/nune"
+	"github.com/vorduin/nune/linalg"
+)
+
+func benchmarkMilli(b *testing.B, f func()) {
+	b.ResetTimer()
+
+	start := time.Now()
+	for i := 0; i < b.N; i++ {
+		f()
+	}
+	execTime := time.Since(start)
+
+	b.ReportMetric(0, "ns/op")
+	b.ReportMetric((1e3*execTime.Seconds())/float64(b.N), "ms/op")
+}
+
+// newMatrix returns a well-conditioned, symmetric
+// positive definite 100 by 100 matrix.
+func newMatrix() nune.Tensor[float64] {
+	return nune.Range[float64](0, 1e4, 1).Reshape(100, 100).Map(func(x float64) float64 {
+		i, j := math.Floor(x/100), math.Mod(x, 100)
+		if i == j {
+			return 200
+		}
+		return 1 / (1 + math.Abs(i-j))
+	})
+}
+
+func BenchmarkLU(b *testing.B) {
+	matrix := newMatrix()
+
+	benchmarkMilli(b, func() {
+		linalg.LU(matrix)
+	})
+}
+
+func BenchmarkQR(b *testing.B) {
+	matrix := newMatrix()
+
+	benchmarkMilli(b, func() {
+		linalg.QR(matrix)
+	})
+}
+
+func BenchmarkCholesky(b *testing.B) {
+	matrix := newMatrix()
+
+	benchmarkMilli(b, func() {
+		linalg.Cholesky(matrix)
+	})
+}
+
+func BenchmarkEigh(b *testing.B) {
+	matrix := newMatrix()
+
+	benchmarkMilli(b, func() {
+		linalg.Eigh(matrix)
+	})
+}
+
+func BenchmarkSVD(b *testing.B) {
+	matrix := newMatrix()
+
+	benchmarkMilli(b, func() {
+		linalg.SVD(matrix)
+	})
+}
+
+func BenchmarkSolve(b *testing.B) {
+	matrix := newMatrix()
+	vector := nune.Range[float64](0, 100, 1)
+
+	benchmarkMilli(b, func() {
+		linalg.Solve(matrix, vector)
+	})
+}
+
+func BenchmarkInv(b *testing.B) {
+	matrix := newMatrix()
+
+	benchmarkMilli(b, func() {
+		linalg.Inv(matrix)
+	})
+}
+
+func BenchmarkDet(b *testing.B) {
+	matrix := newMatrix()
+
+	benchmarkMilli(b, func() {
+		linalg.Det(matrix)
+	})
+}
+
+func TestSingular(t *testing.T) {
+	// the matrix has rank 2, though rounding errors
+	// keep the pivots of its LU decomposition from being null
+	a := nune.Range[float64](1, 10, 1).Reshape(3, 3)
+
+	if inv := linalg.Inv(a); !errors.Is(inv.Err, linalg.ErrSingular) {
+		t.Errorf("Inv: got error %v, want %v", inv.Err, linalg.ErrSingular)
+	}
+
+	b := nune.From[float64]([]float64{1, 2, 3})
+	if x := linalg.Solve(a, b); !errors.Is(x.Err, linalg.ErrSingular) {
+		t.Errorf("Solve: got error %v, want %v", x.Err, linalg.ErrSingular)
+	}
+
+	if det := linalg.Det(a); det.Err != nil || math.Abs(det.Scalar()) > 1e-12 {
+		t.Errorf("Det: got %v, want about 0", det)
+	}
+
+	// the null singular vector is completed into an orthonormal basis
+	_, _, vt := linalg.SVD(a)
+	if vt.Err != nil {
+		t.Fatal(vt.Err)
+	}
+	vvt := vt.MatMul(vt.Permute(1, 0))
+	for i := 0; i < 3; i++ {
+		for j := 0; j < 3; j++ {
+			want := 0.0
+			if i == j {
+				want = 1
+			}
+			if got := vvt.Index(i, j).Scalar(); math.Abs(got-want) > 1e-12 {
+				t.Fatalf("SVD: vt isn't orthonormal: %v", vvt)
+			}
+		}
+	}
+}
@@ -0,0 +1,204 @@
+// Copyright © The Nune Author. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package linalg
+
+import (
+	"math"
+
+	"github.com/vorduin/nune"
+)
+
+// luFactor computes the LU decomposition with partial pivoting of a
+// square matrix in place, storing the strictly lower part of L and U
+// in the matrix. It returns the rows' permutation, its sign, and
+// whether or not the matrix is singular, which it's taken to be if a
+// pivot is at most n * eps times the largest magnitude of its elements.
+func luFactor(a matrix) (perm []int, sign float64, singular bool) {
+	n := a.r
+
+	var scale float64
+	for _, x := range a.data {
+		scale = math.Max(scale, math.Abs(x))
+	}
+	tol := float64(n) * eps * scale
+
+	perm = make([]int, n)
+	for i := range perm {
+		perm[i] = i
+	}
+	sign = 1
+
+	for k := 0; k < n; k++ {
+		p := k
+		for i := k + 1; i < n; i++ {
+			if math.Abs(a.at(i, k)) > math.Abs(a.at(p, k)) {
+				p = i
+			}
+		}
+
+		if p != k {
+			for j := 0; j < n; j++ {
+				x := a.at(k, j)
+				a.set(k, j, a.at(p, j))
+				a.set(p, j, x)
+			}
+			perm[k], perm[p] = perm[p], perm[k]
+			sign = -sign
+		}
+
+		// a tiny pivot is still eliminated with,
+		// so that the decomposition remains exact
+		pivot := a.at(k, k)
+		if math.Abs(pivot) <= tol {
+			singular = true
+		}
+		if pivot == 0 {
+			continue
+		}
+
+		for i := k + 1; i < n; i++ {
+			f := a.at(i, k) / pivot
+			a.set(i, k, f)
+			for j := k + 1; j < n; j++ {
+				a.set(i, j, a.at(i, j)-f*a.at(k, j))
+			}
+		}
+	}
+
+	return perm, sign, singular
+}
+
+// luSolve solves the system A * X = B given the
+// LU decomposition of A and its rows' permutation.
+func luSolve(lu matrix, perm []int, b matrix) matrix {
+	n := lu.r
+	x := newMatrix(n, b.c)
+
+	for col := 0; col < b.c; col++ {
+		// forward substitution with the unit lower triangle
+		for i := 0; i < n; i++ {
+			s := b.at(perm[i], col)
+			for j := 0; j < i; j++ {
+				s -= lu.at(i, j) * x.at(j, col)
+			}
+			x.set(i, col, s)
+		}
+
+		// back substitution with the upper triangle
+		for i := n - 1; i >= 0; i-- {
+			s := x.at(i, col)
+			for j := i + 1; j < n; j++ {
+				s -= lu.at(i, j) * x.at(j, col)
+			}
+			x.set(i, col, s/lu.at(i, i))
+		}
+	}
+
+	return x
+}
+
+// LU returns the LU decomposition with partial pivoting of the
+// square matrix a, such that a = p * l * u, where p is a permutation
+// matrix, l is unit lower triangular and u is upper triangular.
+func LU[T Float](a nune.Tensor[T]) (p, l, u nune.Tensor[T]) {
+	e := a.Engine()
+
+	m, err := toSquareMatrix(a)
+	if err != nil {
+		return fail[T](e, err), fail[T](e, err), fail[T](e, err)
+	}
+
+	perm, _, _ := luFactor(m)
+
+	n := m.r
+	pm, lm, um := newMatrix(n, n), newMatrix(n, n), newMatrix(n, n)
+
+	for i := 0; i < n; i++ {
+		pm.set(perm[i], i, 1)
+		lm.set(i, i, 1)
+
+		for j := 0; j < n; j++ {
+			if j < i {
+				lm.set(i, j, m.at(i, j))
+			} else {
+				um.set(i, j, m.at(i, j))
+			}
+		}
+	}
+
+	return fromMatrix[T](e, pm), fromMatrix[T](e, lm), fromMatrix[T](e, um)
+}
+
+// Det returns the determinant of the square matrix a.
+func Det[T Float](a nune.Tensor[T]) nune.Tensor[T] {
+	e := a.Engine()
+
+	m, err := toSquareMatrix(a)
+	if err != nil {
+		return fail[T](e, err)
+	}
+
+	// the determinant of a nearly singular matrix is
+	// tiny rather than null, as its pivots aren't null
+	_, det, _ := luFactor(m)
+
+	for i := 0; i < m.r; i++ {
+		det *= m.at(i, i)
+	}
+
+	return fromScalar[T](e, det)
+}
+
+// Solve solves the linear system a * x = b for x, where a is
+// a square matrix and b is either a vector or a matrix whose
+// columns are the right-hand sides of the system.
+// It fails with ErrSingular if a pivot of the LU decomposition of a
+// is at most n * eps times the largest magnitude of a's elements,
+// rather than only if it's exactly zero as with LAPACK.
+func Solve[T Float](a, b nune.Tensor[T]) nune.Tensor[T] {
+	e := a.Engine()
+
+	m, err := toSquareMatrix(a)
+	if err != nil {
+		return fail[T](e, err)
+	}
+
+	rhs, err := toRHS(b, m.r)
+	if err != nil {
+		return fail[T](e, err)
+	}
+
+	perm, _, singular := luFactor(m)
+	if singular {
+		return fail[T](e, ErrSingular)
+	}
+
+	x := luSolve(m, perm, rhs)
+	if b.Rank() == 1 {
+		return fromVector[T](e, x.data)
+	}
+
+	return fromMatrix[T](e, x)
+}
+
+// Inv returns the inverse of the square matrix a.
+// As with Solve, it fails with ErrSingular if a pivot of the
+// LU decomposition of a is at most n * eps times the largest
+// magnitude of a's elements.
+func Inv[T Float](a nune.Tensor[T]) nune.Tensor[T] {
+	e := a.Engine()
+
+	m, err := toSquareMatrix(a)
+	if err != nil {
+		return fail[T](e, err)
+	}
+
+	perm, _, singular := luFactor(m)
+	if singular {
+		return fail[T](e, ErrSingular)
+	}
+
+	return fromMatrix[T](e, luSolve(m, perm, identity(m.r)))
+}
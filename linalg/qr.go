@@ -0,0 +1,149 @@
+// Copyright © The Nune Author. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package linalg
+
+import (
+	"math"
+
+	"github.com/vorduin/nune"
+)
+
+// QR returns the reduced QR decomposition of the r by c matrix a,
+// computed with Householder reflections, such that a = q * r,
+// where q has orthonormal columns and r is upper triangular.
+// With k being the minimum of r and c, q is r by k and r is k by c.
+func QR[T Float](a nune.Tensor[T]) (q, r nune.Tensor[T]) {
+	e := a.Engine()
+
+	m, err := toMatrix(a)
+	if err != nil {
+		return fail[T](e, err), fail[T](e, err)
+	}
+
+	rows, cols := m.r, m.c
+	k := rows
+	if cols < k {
+		k = cols
+	}
+
+	// the Householder vectors, one per column
+	vs := make([][]float64, k)
+
+	for j := 0; j < k; j++ {
+		var norm float64
+		for i := j; i < rows; i++ {
+			norm = math.Hypot(norm, m.at(i, j))
+		}
+
+		v := make([]float64, rows-j)
+		for i := j; i < rows; i++ {
+			v[i-j] = m.at(i, j)
+		}
+
+		alpha := -math.Copysign(norm, v[0])
+		v[0] -= alpha
+
+		var vnorm float64
+		for _, x := range v {
+			vnorm += x * x
+		}
+
+		vs[j] = v
+		if vnorm == 0 {
+			continue
+		}
+
+		// apply the reflection I - 2vv'/v'v to the remaining columns
+		for c := j; c < cols; c++ {
+			var dot float64
+			for i := j; i < rows; i++ {
+				dot += v[i-j] * m.at(i, c)
+			}
+
+			f := 2 * dot / vnorm
+			for i := j; i < rows; i++ {
+				m.set(i, c, m.at(i, c)-f*v[i-j])
+			}
+		}
+	}
+
+	rm := newMatrix(k, cols)
+	for i := 0; i < k; i++ {
+		for j := i; j < cols; j++ {
+			rm.set(i, j, m.at(i, j))
+		}
+	}
+
+	// accumulate the reflections backwards on the first k columns of I
+	qm := newMatrix(rows, k)
+	for i := 0; i < k; i++ {
+		qm.set(i, i, 1)
+	}
+
+	for j := k - 1; j >= 0; j-- {
+		v := vs[j]
+
+		var vnorm float64
+		for _, x := range v {
+			vnorm += x * x
+		}
+		if vnorm == 0 {
+			continue
+		}
+
+		for c := 0; c < k; c++ {
+			var dot float64
+			for i := j; i < rows; i++ {
+				dot += v[i-j] * qm.at(i, c)
+			}
+
+			f := 2 * dot / vnorm
+			for i := j; i < rows; i++ {
+				qm.set(i, c, qm.at(i, c)-f*v[i-j])
+			}
+		}
+	}
+
+	return fromMatrix[T](e, qm), fromMatrix[T](e, rm)
+}
+
+// Cholesky returns the lower triangular matrix l of the Cholesky
+// decomposition of the symmetric positive definite matrix a,
+// such that a = l * l'. Only the lower triangle of a is read.
+func Cholesky[T Float](a nune.Tensor[T]) nune.Tensor[T] {
+	e := a.Engine()
+
+	m, err := toSquareMatrix(a)
+	if err != nil {
+		return fail[T](e, err)
+	}
+
+	n := m.r
+	l := newMatrix(n, n)
+
+	for j := 0; j < n; j++ {
+		d := m.at(j, j)
+		for k := 0; k < j; k++ {
+			d -= l.at(j, k) * l.at(j, k)
+		}
+
+		if d <= 0 || math.IsNaN(d) {
+			return fail[T](e, ErrNotPositiveDefinite)
+		}
+
+		d = math.Sqrt(d)
+		l.set(j, j, d)
+
+		for i := j + 1; i < n; i++ {
+			s := m.at(i, j)
+			for k := 0; k < j; k++ {
+				s -= l.at(i, k) * l.at(j, k)
+			}
+			l.set(i, j, s/d)
+		}
+	}
+
+	return fromMatrix[T](e, l)
+}
@@ -0,0 +1,251 @@
+// Copyright © The Nune Author. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package linalg
+
+import (
+	"math"
+
+	"github.com/vorduin/nune"
+)
+
+// jacobiSVD computes the reduced singular value decomposition of a
+// matrix with at least as many rows as columns in place, using the
+// one-sided Jacobi algorithm. It returns the matrix of left singular
+// vectors, the singular values, the matrix of right singular vectors,
+// and whether or not it converged.
+func jacobiSVD(a matrix) (matrix, []float64, matrix, bool) {
+	rows, cols := a.r, a.c
+	v := identity(cols)
+
+	converged := false
+	for sweep := 0; sweep < maxSweeps && !converged; sweep++ {
+		converged = true
+
+		for p := 0; p < cols-1; p++ {
+			for q := p + 1; q < cols; q++ {
+				var alpha, beta, gamma float64
+				for i := 0; i < rows; i++ {
+					alpha += a.at(i, p) * a.at(i, p)
+					beta += a.at(i, q) * a.at(i, q)
+					gamma += a.at(i, p) * a.at(i, q)
+				}
+
+				if math.Abs(gamma) <= eps*math.Sqrt(alpha*beta) {
+					continue
+				}
+				converged = false
+
+				// the rotation orthogonalizing columns p and q
+				zeta := (beta - alpha) / (2 * gamma)
+				t := math.Copysign(1, zeta) / (math.Abs(zeta) + math.Hypot(zeta, 1))
+				c := 1 / math.Hypot(t, 1)
+				s := t * c
+
+				for i := 0; i < rows; i++ {
+					aip, aiq := a.at(i, p), a.at(i, q)
+					a.set(i, p, c*aip-s*aiq)
+					a.set(i, q, s*aip+c*aiq)
+				}
+
+				for i := 0; i < cols; i++ {
+					vip, viq := v.at(i, p), v.at(i, q)
+					v.set(i, p, c*vip-s*viq)
+					v.set(i, q, s*vip+c*viq)
+				}
+			}
+		}
+	}
+
+	s := make([]float64, cols)
+	for j := 0; j < cols; j++ {
+		var norm float64
+		for i := 0; i < rows; i++ {
+			norm = math.Hypot(norm, a.at(i, j))
+		}
+
+		s[j] = norm
+		if norm != 0 {
+			for i := 0; i < rows; i++ {
+				a.set(i, j, a.at(i, j)/norm)
+			}
+		}
+	}
+
+	_, v = sortColumns(s, v, true)
+	s, u := sortColumns(s, a, true)
+
+	return u, s, v, converged
+}
+
+// cutoff returns the relative cutoff below which the singular values
+// of an r by c matrix, in descending order, are treated as null.
+func cutoff(r, c int, s []float64) float64 {
+	if len(s) == 0 {
+		return 0
+	}
+
+	dim := r
+	if c > dim {
+		dim = c
+	}
+
+	return float64(dim) * eps * s[0]
+}
+
+// completeColumns replaces the null columns of a matrix, the ones
+// of its singular values that are at most the cutoff, with unit
+// vectors that are orthogonal to all the other columns.
+func completeColumns(m matrix, s []float64, cutoff float64) {
+	for j := range s {
+		if s[j] > cutoff {
+			continue
+		}
+
+		for e := 0; e < m.r; e++ {
+			v := make([]float64, m.r)
+			v[e] = 1
+
+			// Gram-Schmidt against the other columns
+			for k := 0; k < m.c; k++ {
+				if k == j || (s[k] <= cutoff && k > j) {
+					continue
+				}
+
+				var dot float64
+				for i := 0; i < m.r; i++ {
+					dot += v[i] * m.at(i, k)
+				}
+				for i := 0; i < m.r; i++ {
+					v[i] -= dot * m.at(i, k)
+				}
+			}
+
+			var norm float64
+			for _, x := range v {
+				norm = math.Hypot(norm, x)
+			}
+
+			if norm > 1e-8 {
+				for i := 0; i < m.r; i++ {
+					m.set(i, j, v[i]/norm)
+				}
+				break
+			}
+		}
+	}
+}
+
+// svd computes the reduced singular value decomposition of a matrix,
+// returning the singular values in descending order.
+func svd(m matrix) (u matrix, s []float64, v matrix, ok bool) {
+	if m.r >= m.c {
+		u, s, v, ok = jacobiSVD(m.clone())
+	} else {
+		v, s, u, ok = jacobiSVD(m.transpose())
+	}
+
+	if ok {
+		c := cutoff(m.r, m.c, s)
+		completeColumns(u, s, c)
+		completeColumns(v, s, c)
+	}
+
+	return u, s, v, ok
+}
+
+// SVD returns the reduced singular value decomposition of the
+// r by c matrix a, such that a = u * diag(s) * vt, where, with k
+// being the minimum of r and c, u is r by k, s holds the k singular
+// values in descending order, and vt is k by c.
+func SVD[T Float](a nune.Tensor[T]) (u, s, vt nune.Tensor[T]) {
+	e := a.Engine()
+
+	m, err := toMatrix(a)
+	if err != nil {
+		return fail[T](e, err), fail[T](e, err), fail[T](e, err)
+	}
+
+	um, sv, vm, ok := svd(m)
+	if !ok {
+		return fail[T](e, ErrNoConvergence), fail[T](e, ErrNoConvergence), fail[T](e, ErrNoConvergence)
+	}
+
+	return fromMatrix[T](e, um), fromVector[T](e, sv), fromMatrix[T](e, vm.transpose())
+}
+
+// pinv returns the Moore-Penrose pseudo-inverse of a matrix,
+// treating the singular values below a relative cutoff as null.
+func pinv(m matrix) (matrix, bool) {
+	u, s, v, ok := svd(m)
+	if !ok {
+		return matrix{}, false
+	}
+
+	c := cutoff(m.r, m.c, s)
+
+	// v * diag(1/s) * u'
+	p := newMatrix(m.c, m.r)
+	for k, x := range s {
+		if x <= c {
+			continue
+		}
+
+		for i := 0; i < m.c; i++ {
+			f := v.at(i, k) / x
+			for j := 0; j < m.r; j++ {
+				p.data[i*p.c+j] += f * u.at(j, k)
+			}
+		}
+	}
+
+	return p, true
+}
+
+// Pinv returns the Moore-Penrose pseudo-inverse of the matrix a,
+// computed from its singular value decomposition.
+func Pinv[T Float](a nune.Tensor[T]) nune.Tensor[T] {
+	e := a.Engine()
+
+	m, err := toMatrix(a)
+	if err != nil {
+		return fail[T](e, err)
+	}
+
+	p, ok := pinv(m)
+	if !ok {
+		return fail[T](e, ErrNoConvergence)
+	}
+
+	return fromMatrix[T](e, p)
+}
+
+// Lstsq returns the minimum norm least-squares solution x to the
+// linear system a * x = b, where b is either a vector or a matrix
+// whose columns are the right-hand sides of the system.
+func Lstsq[T Float](a, b nune.Tensor[T]) nune.Tensor[T] {
+	e := a.Engine()
+
+	m, err := toMatrix(a)
+	if err != nil {
+		return fail[T](e, err)
+	}
+
+	rhs, err := toRHS(b, m.r)
+	if err != nil {
+		return fail[T](e, err)
+	}
+
+	p, ok := pinv(m)
+	if !ok {
+		return fail[T](e, ErrNoConvergence)
+	}
+
+	x := p.mul(rhs)
+	if b.Rank() == 1 {
+		return fromVector[T](e, x.data)
+	}
+
+	return fromMatrix[T](e, x)
+}
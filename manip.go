@@ -5,15 +5,13 @@
 package nune
 
 import (
-	"errors"
-
 	"github.com/vorduin/slices"
 )
 
 // Cast casts a Tensor's underlying type to the given numeric type.
 func Cast[T Number, V Number](t Tensor[V]) Tensor[T] {
 	if t.Err != nil {
-		if EnvConfig.Interactive {
+		if t.env().Interactive {
 			panic(t.Err)
 		} else {
 			return Tensor[T]{
@@ -22,41 +20,44 @@ func Cast[T Number, V Number](t Tensor[V]) Tensor[T] {
 		}
 	}
 
-	dataBuf := t.Ravel()
 	c := slices.WithLen[T](t.Numel())
-	for i := 0; i < len(c); i++ {
-		c[i] = T(dataBuf[i])
-	}
+	copyView(c, t)
 
 	return Tensor[T]{
 		data:   c,
-		shape:  t.shape,
-		stride: t.stride,
-		offset: t.offset,
+		shape:  slices.Clone(t.shape),
+		stride: configStride(t.shape),
+		eng:    t.eng,
 	}
 }
 
-// Clone clones the Tensor and its underlying view into its data buffer.
+// Clone clones the Tensor's view into a new, contiguous data buffer.
 func (t Tensor[T]) Clone() Tensor[T] {
 	if t.Err != nil {
-		if EnvConfig.Interactive {
+		if t.env().Interactive {
 			panic(t.Err)
 		} else {
 			return t
 		}
 	}
 
+	data := slices.WithLen[T](t.Numel())
+	copyView(data, t)
+
 	return Tensor[T]{
-		data:   slices.Clone(t.Ravel()),
+		data:   data,
 		shape:  slices.Clone(t.shape),
-		stride: slices.Clone(t.stride),
+		stride: configStride(t.shape),
+		eng:    t.eng,
 	}
 }
 
 // Reshape modifies the Tensor's indexing scheme.
+// If the Tensor's view is not contiguous, the view
+// is first copied into a compact data buffer.
 func (t Tensor[T]) Reshape(shape ...int) Tensor[T] {
 	if t.Err != nil {
-		if EnvConfig.Interactive {
+		if t.env().Interactive {
 			panic(t.Err)
 		} else {
 			return t
@@ -67,33 +68,31 @@ func (t Tensor[T]) Reshape(shape ...int) Tensor[T] {
 		return Tensor[T]{
 			data:   t.data,
 			offset: t.offset,
+			eng:    t.eng,
 		}
 	} else {
 		err := verifyGoodShape(shape...)
+		if err == nil && slices.Prod(shape) != t.Numel() {
+			err = ErrBadShape
+		}
 		if err != nil {
-			if EnvConfig.Interactive {
+			err = newOpError("Reshape", err, -1, t.shape, shape)
+			if t.env().Interactive {
 				panic(err)
 			} else {
 				t.Err = err
 				return t
 			}
 		}
-		
-		newstride := slices.WithLen[int](len(shape))
-		if len(shape) <= len(t.shape) {
-			copy(newstride, t.stride[len(t.stride)-len(shape):])
-		} else {
-			copy(newstride[len(shape)-len(t.stride):], t.stride)
-			for i := len(shape)-len(t.stride)-1; i >= 0; i-- {
-				newstride[i] = shape[i+1] * newstride[i+1]
-			}
-		}
+
+		t = t.Contiguous()
 
 		return Tensor[T]{
 			data:   t.data,
 			shape:  slices.Clone(shape),
-			stride: newstride,
+			stride: configStride(shape),
 			offset: t.offset,
+			eng:    t.eng,
 		}
 	}
 }
@@ -102,7 +101,7 @@ func (t Tensor[T]) Reshape(shape ...int) Tensor[T] {
 // Multiple indices can be provided at the same time.
 func (t Tensor[T]) Index(indices ...int) Tensor[T] {
 	if t.Err != nil {
-		if EnvConfig.Interactive {
+		if t.env().Interactive {
 			panic(t.Err)
 		} else {
 			return t
@@ -111,7 +110,8 @@ func (t Tensor[T]) Index(indices ...int) Tensor[T] {
 
 	err := verifyArgsBounds(len(indices), t.Rank())
 	if err != nil {
-		if EnvConfig.Interactive {
+		err = newOpError("Index", err, -1, t.shape)
+		if t.env().Interactive {
 			panic(err)
 		} else {
 			t.Err = err
@@ -120,9 +120,10 @@ func (t Tensor[T]) Index(indices ...int) Tensor[T] {
 	}
 
 	for i, idx := range indices {
-		err = verifyAxisBounds(idx, t.Size(i))
+		err = verifyAxisBounds(idx, t.Size(i)-1)
 		if err != nil {
-			if EnvConfig.Interactive {
+			err = newOpError("Index", err, i, t.shape)
+			if t.env().Interactive {
 				panic(err)
 			} else {
 				t.Err = err
@@ -142,13 +143,14 @@ func (t Tensor[T]) Index(indices ...int) Tensor[T] {
 		shape:  slices.Clone(t.shape[len(indices):]),
 		stride: slices.Clone(t.stride[len(indices):]),
 		offset: offset,
+		eng:    t.eng,
 	}
 }
 
 // Slice returns a view over a slice of the Tensor.
 func (t Tensor[T]) Slice(start, end int) Tensor[T] {
 	if t.Err != nil {
-		if EnvConfig.Interactive {
+		if t.env().Interactive {
 			panic(t.Err)
 		} else {
 			return t
@@ -157,7 +159,8 @@ func (t Tensor[T]) Slice(start, end int) Tensor[T] {
 
 	err := verifyGoodShape(t.shape...) // make sure Tensor rank is not 0
 	if err != nil {
-		if EnvConfig.Interactive {
+		err = newOpError("Slice", err, 0, t.shape)
+		if t.env().Interactive {
 			panic(err)
 		} else {
 			t.Err = err
@@ -167,7 +170,8 @@ func (t Tensor[T]) Slice(start, end int) Tensor[T] {
 
 	err = verifyGoodInterval(start, end, [2]int{0, t.Size(0)})
 	if err != nil {
-		if EnvConfig.Interactive {
+		err = newOpError("Slice", err, 0, t.shape)
+		if t.env().Interactive {
 			panic(err)
 		} else {
 			t.Err = err
@@ -184,13 +188,25 @@ func (t Tensor[T]) Slice(start, end int) Tensor[T] {
 		shape:  shape,
 		stride: slices.Clone(t.stride),
 		offset: t.offset + start*t.stride[0],
+		eng:    t.eng,
 	}
 }
 
-// Broadcast broadcasts the Tensor to the given shape.
+// Broadcast broadcasts the Tensor to the given shape,
+// copying its data into a new buffer of that shape.
 func (t Tensor[T]) Broadcast(shape ...int) Tensor[T] {
+	return t.Expand(shape...).Clone()
+}
+
+// Expand returns a view of the Tensor broadcast to the given shape
+// without copying its data, where the broadcast axes are walked over
+// with a stride of 0, so that all their indices share the same elements.
+// As in NumPy, the view is read-only: the in-place operations on it,
+// such as the pointwise operations or Assign, fail with ErrReadOnly.
+// Broadcast copies the Tensor into a buffer that can be written to.
+func (t Tensor[T]) Expand(shape ...int) Tensor[T] {
 	if t.Err != nil {
-		if EnvConfig.Interactive {
+		if t.env().Interactive {
 			panic(t.Err)
 		} else {
 			return t
@@ -198,74 +214,82 @@ func (t Tensor[T]) Broadcast(shape ...int) Tensor[T] {
 	}
 
 	if !t.Broadable(shape...) {
-		if EnvConfig.Interactive {
-			panic(ErrNotBroadable)
+		err := newOpError("Expand", ErrNotBroadable, -1, t.shape, shape)
+		if t.env().Interactive {
+			panic(err)
 		} else {
-			t.Err = ErrNotBroadable
+			t.Err = err
 			return t
 		}
 	}
 
-	var expandedShape []int
-
-	if len(t.shape) < len(shape) {
-		expandedShape = slices.WithLen[int](len(shape))
-		for i := 0; i < len(shape)-len(t.shape); i++ {
-			expandedShape[i] = 1
+	newstride := slices.WithLen[int](len(shape))
+	lead := len(shape) - len(t.shape)
+	for i := lead; i < len(shape); i++ {
+		if t.shape[i-lead] == shape[i] {
+			newstride[i] = t.stride[i-lead]
 		}
-		copy(expandedShape[len(shape)-len(t.shape):], t.shape)
-	} else {
-		expandedShape = t.shape
 	}
 
-	expandedStride := configStride(expandedShape)
-	newStride := configStride(shape)
+	return Tensor[T]{
+		data:   t.data,
+		shape:  slices.Clone(shape),
+		stride: newstride,
+		offset: t.offset,
+		eng:    t.eng,
+	}
+}
 
-	data := slices.WithLen[T](int(slices.Prod(shape)))
+// handleSwap swaps the first n elements of two views
+// sharing the same shape and data buffer.
+func handleSwap[T Number](data []T, shape, lstride, rstride []int, loffset, roffset, n int) {
+	if n == 0 {
+		return
+	}
 
-	var expansion, stride int = 1, newStride[0]
+	shape, strides := coalesce(shape, lstride, rstride)
 
-	// This is around 20% slower on average the the shortened version
-	// I also came up with, but this one generalizes correctly so...
-	for axis := 0; axis < len(shape); axis++ {
-		if expandedShape[axis] != shape[axis] {
-			for i := 0; i < expansion; i++ {
-				for j := 0; j < t.Numel()/expandedStride[axis]; j++ {
-					for k := 0; k < shape[axis]; k++ {
-						dstIdx := i*stride + j*shape[axis] + k*newStride[axis]
-						srcIdx := j * expandedStride[axis]
+	lw := newWalker(shape, strides[0], loffset, 0)
+	rw := newWalker(shape, strides[1], roffset, 0)
 
-						copy(data[dstIdx:dstIdx+newStride[axis]], t.Ravel()[srcIdx:srcIdx+expandedStride[axis]])
-					}
-				}
-			}
+	for n > 0 {
+		lpos, lstep, l := lw.run(n)
+		rpos, rstep, _ := rw.run(n)
 
-			expansion *= shape[axis]
-			stride = newStride[axis]
+		for j := 0; j < l; j++ {
+			data[lpos], data[rpos] = data[rpos], data[lpos]
+			lpos += lstep
+			rpos += rstep
 		}
-	}
 
-	return Tensor[T]{
-		data: data,
-		shape: slices.Clone(shape),
-		stride: newStride,
+		n -= l
 	}
 }
 
 // Reverse reverses the order of the elements of the Tensor.
 func (t Tensor[T]) Reverse() Tensor[T] {
 	if t.Err != nil {
-		if EnvConfig.Interactive {
+		if t.env().Interactive {
 			panic(t.Err)
 		} else {
 			return t
 		}
 	}
 
-	for i, j := 0, t.Numel()-1; i < j; i, j = i+1, j-1 {
-		t.data[t.offset+i], t.data[t.offset+j] = t.data[t.offset+j], t.data[t.offset+i]
+	if t, ok := t.writable("Reverse"); !ok {
+		return t
+	}
+
+	// the reversed view walks over every axis backwards
+	stride := slices.WithLen[int](len(t.stride))
+	offset := t.offset
+	for i := range stride {
+		stride[i] = -t.stride[i]
+		offset += (t.shape[i] - 1) * t.stride[i]
 	}
 
+	handleSwap(t.data, t.shape, t.stride, stride, t.offset, offset, t.Numel()/2)
+
 	return t
 }
 
@@ -273,16 +297,21 @@ func (t Tensor[T]) Reverse() Tensor[T] {
 // along the given axis.
 func (t Tensor[T]) Flip(axis int) Tensor[T] {
 	if t.Err != nil {
-		if EnvConfig.Interactive {
+		if t.env().Interactive {
 			panic(t.Err)
 		} else {
 			return t
 		}
 	}
 
-	err := verifyAxisBounds(0, len(t.shape))
+	if t, ok := t.writable("Flip"); !ok {
+		return t
+	}
+
+	err := verifyAxisBounds(axis, len(t.shape)-1)
 	if err != nil {
-		if EnvConfig.Interactive {
+		err = newOpError("Flip", err, axis, t.shape)
+		if t.env().Interactive {
 			panic(err)
 		} else {
 			t.Err = err
@@ -290,14 +319,14 @@ func (t Tensor[T]) Flip(axis int) Tensor[T] {
 		}
 	}
 
-	stride := t.stride[axis]
-	for i := 0; i < t.Numel(); i += t.shape[axis] * stride {
-		for j, k := 0, t.shape[axis]-1; j < k; j, k = j+1, k-1 {
-			for l := 0; l < t.stride[axis]; l++ {
-				t.data[t.offset+i+j*stride+l], t.data[t.offset+i+k*stride+l] = t.data[t.offset+i+k*stride+l], t.data[t.offset+i+j*stride+l]
-			}
-		}
-	}
+	// swap the first half of the axis with its flipped second half
+	shape := slices.Clone(t.shape)
+	shape[axis] /= 2
+	stride := slices.Clone(t.stride)
+	stride[axis] = -t.stride[axis]
+	offset := t.offset + (t.shape[axis]-1)*t.stride[axis]
+
+	handleSwap(t.data, shape, t.stride, stride, t.offset, offset, slices.Prod(shape))
 
 	return t
 }
@@ -305,7 +334,7 @@ func (t Tensor[T]) Flip(axis int) Tensor[T] {
 // Repeat repeats the elements of the array n times.
 func (t Tensor[T]) Repeat(n int) Tensor[T] {
 	if t.Err != nil {
-		if EnvConfig.Interactive {
+		if t.env().Interactive {
 			panic(t.Err)
 		} else {
 			return t
@@ -313,31 +342,28 @@ func (t Tensor[T]) Repeat(n int) Tensor[T] {
 	}
 
 	numel := t.Numel()
-	dataBuf := t.Ravel()
+	dataBuf := t.Contiguous().Ravel()
 	data := slices.WithLen[T](n * numel)
 	for i := 0; i < n; i++ {
 		copy(data[i*numel:i*numel+numel], dataBuf)
 	}
 
 	shape := slices.WithLen[int](len(t.shape) + 1)
-	stride := slices.WithLen[int](len(t.stride) + 1)
-
 	shape[0] = n
 	copy(shape[1:], t.shape)
-	stride[0] = t.shape[0] * t.stride[0]
-	copy(stride[1:], t.stride)
 
 	return Tensor[T]{
 		data: data,
 		shape: shape,
-		stride: stride,
+		stride: configStride(shape),
+		eng: t.eng,
 	}
 }
 
 // Permute permutes the Tensor's axes without changing the data.
 func (t Tensor[T]) Permute(axes ...int) Tensor[T] {
 	if t.Err != nil {
-		if EnvConfig.Interactive {
+		if t.env().Interactive {
 			panic(t.Err)
 		} else {
 			return t
@@ -346,7 +372,8 @@ func (t Tensor[T]) Permute(axes ...int) Tensor[T] {
 
 	err := verifyArgsBounds(len(axes), len(t.shape))
 	if err != nil {
-		if EnvConfig.Interactive {
+		err = newOpError("Permute", err, -1, t.shape)
+		if t.env().Interactive {
 			panic(err)
 		} else {
 			t.Err = err
@@ -361,9 +388,10 @@ func (t Tensor[T]) Permute(axes ...int) Tensor[T] {
 	newstride := slices.WithLen[int](len(t.stride))
 
 	for i, axis := range axes {
-		err := verifyAxisBounds(axis, len(t.shape))
+		err := verifyAxisBounds(axis, len(t.shape)-1)
 		if err != nil {
-			if EnvConfig.Interactive {
+			err = newOpError("Permute", err, axis, t.shape)
+			if t.env().Interactive {
 				panic(err)
 			} else {
 				t.Err = err
@@ -380,13 +408,14 @@ func (t Tensor[T]) Permute(axes ...int) Tensor[T] {
 		shape: newshape,
 		stride: newstride,
 		offset: t.offset,
+		eng: t.eng,
 	}
 }
 
 // Cat concatenates the other Tensor to this Tensor along the given axis.
 func (t Tensor[T]) Cat(other Tensor[T], axis int) Tensor[T] {
 	if t.Err != nil {
-		if EnvConfig.Interactive {
+		if t.env().Interactive {
 			panic(t.Err)
 		} else {
 			return t
@@ -394,17 +423,18 @@ func (t Tensor[T]) Cat(other Tensor[T], axis int) Tensor[T] {
 	}
 
 	if other.Err != nil {
-		if EnvConfig.Interactive {
-			panic("nune: could not concatenate the two tensors")
+		if t.env().Interactive {
+			panic(other.Err)
 		} else {
-			t.Err = errors.New("nune: could not concatenate the two tensors")
+			t.Err = other.Err
 			return t
 		}
 	}
 
-	err := verifyAxisBounds(axis, len(t.shape))
+	err := verifyAxisBounds(axis, len(t.shape)-1)
 	if err != nil {
-		if EnvConfig.Interactive {
+		err = newOpError("Cat", err, axis, t.shape, other.shape)
+		if t.env().Interactive {
 			panic(err)
 		} else {
 			t.Err = err
@@ -412,44 +442,23 @@ func (t Tensor[T]) Cat(other Tensor[T], axis int) Tensor[T] {
 		}
 	}
 
-	if !slices.Equal(t.shape[:axis], other.shape[:axis]) || !slices.Equal(t.shape[axis+1:], other.shape[axis+1:]) {
-		if EnvConfig.Interactive {
-			panic("nune: tensors' shapes do not allow concatenating them")
+	if len(t.shape) != len(other.shape) || !slices.Equal(t.shape[:axis], other.shape[:axis]) || !slices.Equal(t.shape[axis+1:], other.shape[axis+1:]) {
+		err := newOpError("Cat", ErrShapeMismatch, axis, t.shape, other.shape)
+		if t.env().Interactive {
+			panic(err)
 		} else {
-			t.Err = errors.New(("nune: tensors' shapes do not allow concatenating them"))
+			t.Err = err
 			return t
 		}
 	}
 
-	newshape := slices.Clone(t.shape)
-	newshape[axis] += other.shape[axis]
-	newstride := configStride(newshape)
-
-	ts := t.stride[axis]
-	os := other.stride[axis]
-	ns := newstride[axis]
-	data := slices.WithLen[T](t.Numel() + other.Numel())
-
-	// how do I come up with these algorithms...
-	for i := 0; i < t.Numel()/(ts*t.shape[axis]); i++ {
-		copy(data[i*ns*newshape[axis]:i*ns*newshape[axis]+ts*t.shape[axis]], t.Ravel()[i*ts*t.shape[axis]:(i+1)*ts*t.shape[axis]])
-	}
-
-	for i := 0; i < other.Numel()/(os*other.shape[axis]); i++ {
-		copy(data[i*ns*newshape[axis]+ts*t.shape[axis]:(i+1)*ns*newshape[axis]], other.Ravel()[i*os*other.shape[axis]:(i+1)*os*other.shape[axis]])
-	}
-
-	return Tensor[T]{
-		data: data,
-		shape: newshape,
-		stride: newstride,
-	}
+	return Concat(axis, t, other)
 }
 
 // Stack stacks this and the other Tensor together along a new axis.
 func (t Tensor[T]) Stack(other Tensor[T], axis int) Tensor[T] {
 	if t.Err != nil {
-		if EnvConfig.Interactive {
+		if t.env().Interactive {
 			panic(t.Err)
 		} else {
 			return t
@@ -457,17 +466,21 @@ func (t Tensor[T]) Stack(other Tensor[T], axis int) Tensor[T] {
 	}
 
 	if other.Err != nil {
-		if EnvConfig.Interactive {
-			panic("nune: could not concatenate the two tensors")
+		if t.env().Interactive {
+			panic(other.Err)
 		} else {
-			t.Err = errors.New("nune: could not concatenate the two tensors")
+			t.Err = other.Err
 			return t
 		}
 	}
 
 	err := verifyAxisBounds(axis, len(t.shape))
+	if err == nil && !slices.Equal(t.shape, other.shape) {
+		err = ErrShapeMismatch
+	}
 	if err != nil {
-		if EnvConfig.Interactive {
+		err = newOpError("Stack", err, axis, t.shape, other.shape)
+		if t.env().Interactive {
 			panic(err)
 		} else {
 			t.Err = err
@@ -485,16 +498,20 @@ func (t Tensor[T]) Stack(other Tensor[T], axis int) Tensor[T] {
 // Squeeze removes an axis of dimensions 1 from the Tensor's shape.
 func (t Tensor[T]) Squeeze(axis int) Tensor[T] {
 	if t.Err != nil {
-		if EnvConfig.Interactive {
+		if t.env().Interactive {
 			panic(t.Err)
 		} else {
 			return t
 		}
 	}
 
-	err := verifyAxisBounds(axis, len(t.shape))
+	err := verifyAxisBounds(axis, len(t.shape)-1)
+	if err == nil && t.shape[axis] > 1 {
+		err = ErrNotSqueezable
+	}
 	if err != nil {
-		if EnvConfig.Interactive {
+		err = newOpError("Squeeze", err, axis, t.shape)
+		if t.env().Interactive {
 			panic(err)
 		} else {
 			t.Err = err
@@ -502,15 +519,6 @@ func (t Tensor[T]) Squeeze(axis int) Tensor[T] {
 		}
 	}
 
-	if t.shape[axis] > 1 {
-		if EnvConfig.Interactive {
-			panic("nune: tensor axis dimensions greater than 1")
-		} else {
-			t.Err = errors.New("nune: tensor axis dimensions greater than 1")
-			return t
-		}
-	}
-
 	newshape := slices.WithLen[int](len(t.shape) - 1)
 	newstride := slices.WithLen[int](len(t.stride) - 1)
 
@@ -524,13 +532,14 @@ func (t Tensor[T]) Squeeze(axis int) Tensor[T] {
 		shape: newshape,
 		stride: newstride,
 		offset: t.offset,
+		eng: t.eng,
 	}
 }
 
 // Unsqueeze adds an axis of dimensions 1 to the Tensor's shape.
 func (t Tensor[T]) Unsqueeze(axis int) Tensor[T] {
 	if t.Err != nil {
-		if EnvConfig.Interactive {
+		if t.env().Interactive {
 			panic(t.Err)
 		} else {
 			return t
@@ -539,7 +548,8 @@ func (t Tensor[T]) Unsqueeze(axis int) Tensor[T] {
 
 	err := verifyAxisBounds(axis, len(t.shape))
 	if err != nil {
-		if EnvConfig.Interactive {
+		err = newOpError("Unsqueeze", err, axis, t.shape)
+		if t.env().Interactive {
 			panic(err)
 		} else {
 			t.Err = err
@@ -567,5 +577,669 @@ func (t Tensor[T]) Unsqueeze(axis int) Tensor[T] {
 		shape: newshape,
 		stride: newstride,
 		offset: t.offset,
+		eng: t.eng,
+	}
+}
+
+// Concat concatenates the given Tensors along the given axis, copying
+// them into a single buffer. The Tensors must all have the same shape,
+// except along the axis. The result is bound to the first Tensor's Engine.
+func Concat[T Number](axis int, ts ...Tensor[T]) Tensor[T] {
+	if len(ts) == 0 {
+		err := newOpError("Concat", ErrBadShape, axis)
+		if EnvConfig.Interactive {
+			panic(err)
+		} else {
+			return Tensor[T]{
+				Err: err,
+			}
+		}
+	}
+
+	t := ts[0]
+
+	var err error
+	for _, x := range ts {
+		if x.Err != nil {
+			err = x.Err
+			break
+		}
+	}
+
+	if err == nil {
+		err = verifyAxisBounds(axis, len(t.shape)-1)
+		for _, x := range ts[1:] {
+			if err == nil && (len(x.shape) != len(t.shape) || !slices.Equal(x.shape[:axis], t.shape[:axis]) || !slices.Equal(x.shape[axis+1:], t.shape[axis+1:])) {
+				err = ErrShapeMismatch
+			}
+		}
+
+		if err != nil {
+			shapes := make([][]int, len(ts))
+			for i, x := range ts {
+				shapes[i] = x.shape
+			}
+			err = newOpError("Concat", err, axis, shapes...)
+		}
+	}
+
+	if err != nil {
+		if t.env().Interactive {
+			panic(err)
+		} else {
+			t.Err = err
+			return t
+		}
+	}
+
+	shape := slices.Clone(t.shape)
+	for _, x := range ts[1:] {
+		shape[axis] += x.shape[axis]
+	}
+	stride := configStride(shape)
+
+	data := slices.WithLen[T](slices.Prod(shape))
+
+	// each Tensor is copied into its region of the buffer
+	offset := 0
+	for _, x := range ts {
+		offsets := []int{offset, x.offset}
+		strides := [][]int{stride, x.stride}
+
+		handleViews(x.shape, offsets, strides, t.env().configCPU(x.Numel()), func(_ int, pos, step []int, l int) {
+			if step[0] == 1 && step[1] == 1 {
+				copy(data[pos[0]:pos[0]+l], x.data[pos[1]:pos[1]+l])
+				return
+			}
+
+			for j := 0; j < l; j++ {
+				data[pos[0]] = x.data[pos[1]]
+				pos[0] += step[0]
+				pos[1] += step[1]
+			}
+		})
+
+		offset += x.shape[axis] * stride[axis]
+	}
+
+	return Tensor[T]{
+		data:   data,
+		shape:  shape,
+		stride: stride,
+		eng:    t.eng,
+	}
+}
+
+// StackAll stacks the given Tensors together along a new axis, copying
+// them into a single buffer. The Tensors must all have the same shape.
+// The result is bound to the first Tensor's Engine.
+func StackAll[T Number](axis int, ts ...Tensor[T]) Tensor[T] {
+	if len(ts) == 0 {
+		err := newOpError("StackAll", ErrBadShape, axis)
+		if EnvConfig.Interactive {
+			panic(err)
+		} else {
+			return Tensor[T]{
+				Err: err,
+			}
+		}
+	}
+
+	t := ts[0]
+
+	var err error
+	for _, x := range ts {
+		if x.Err != nil {
+			err = x.Err
+			break
+		}
+	}
+
+	if err == nil {
+		err = verifyAxisBounds(axis, len(t.shape))
+		for _, x := range ts[1:] {
+			if err == nil && !slices.Equal(x.shape, t.shape) {
+				err = ErrShapeMismatch
+			}
+		}
+
+		if err != nil {
+			shapes := make([][]int, len(ts))
+			for i, x := range ts {
+				shapes[i] = x.shape
+			}
+			err = newOpError("StackAll", err, axis, shapes...)
+		}
+	}
+
+	if err != nil {
+		if t.env().Interactive {
+			panic(err)
+		} else {
+			t.Err = err
+			return t
+		}
+	}
+
+	views := make([]Tensor[T], len(ts))
+	for i, x := range ts {
+		views[i] = x.Unsqueeze(axis)
+	}
+
+	return Concat(axis, views...)
+}
+
+// Split splits the Tensor along the given axis into views of the
+// given sizes, which must add up to the axis' dimensions.
+// If the split fails, the only returned Tensor holds the error.
+func (t Tensor[T]) Split(sizes []int, axis int) []Tensor[T] {
+	if t.Err != nil {
+		if t.env().Interactive {
+			panic(t.Err)
+		} else {
+			return []Tensor[T]{t}
+		}
+	}
+
+	err := verifyAxisBounds(axis, len(t.shape)-1)
+	if err == nil {
+		err = verifyGoodShape(sizes...)
+	}
+	if err == nil && slices.Sum(sizes) != t.shape[axis] {
+		err = ErrShapeMismatch
+	}
+	if err != nil {
+		err = newOpError("Split", err, axis, t.shape, sizes)
+		if t.env().Interactive {
+			panic(err)
+		} else {
+			t.Err = err
+			return []Tensor[T]{t}
+		}
+	}
+
+	views := make([]Tensor[T], len(sizes))
+
+	offset := t.offset
+	for i, size := range sizes {
+		shape := slices.Clone(t.shape)
+		shape[axis] = size
+
+		views[i] = Tensor[T]{
+			data:   t.data,
+			shape:  shape,
+			stride: slices.Clone(t.stride),
+			offset: offset,
+			eng:    t.eng,
+		}
+
+		offset += size * t.stride[axis]
+	}
+
+	return views
+}
+
+// Chunk splits the Tensor along the given axis into n views, whose
+// dimensions along the axis differ by at most 1, the first ones being
+// the largest. n must be at most the axis' dimensions.
+// If the split fails, the only returned Tensor holds the error.
+func (t Tensor[T]) Chunk(n, axis int) []Tensor[T] {
+	if t.Err != nil {
+		if t.env().Interactive {
+			panic(t.Err)
+		} else {
+			return []Tensor[T]{t}
+		}
+	}
+
+	err := verifyAxisBounds(axis, len(t.shape)-1)
+	if err == nil && (n <= 0 || n > t.shape[axis]) {
+		err = ErrBadShape
+	}
+	if err != nil {
+		err = newOpError("Chunk", err, axis, t.shape)
+		if t.env().Interactive {
+			panic(err)
+		} else {
+			t.Err = err
+			return []Tensor[T]{t}
+		}
+	}
+
+	// the remainder of the split is spread over the first chunks
+	d := t.shape[axis]
+	sizes := slices.WithLen[int](n)
+	for i := range sizes {
+		sizes[i] = d / n
+		if i < d%n {
+			sizes[i]++
+		}
+	}
+
+	return t.Split(sizes, axis)
+}
+
+// Unbind returns the views over each index
+// of the given axis of the Tensor.
+// If the operation fails, the only returned Tensor holds the error.
+func (t Tensor[T]) Unbind(axis int) []Tensor[T] {
+	if t.Err != nil {
+		if t.env().Interactive {
+			panic(t.Err)
+		} else {
+			return []Tensor[T]{t}
+		}
+	}
+
+	err := verifyAxisBounds(axis, len(t.shape)-1)
+	if err != nil {
+		err = newOpError("Unbind", err, axis, t.shape)
+		if t.env().Interactive {
+			panic(err)
+		} else {
+			t.Err = err
+			return []Tensor[T]{t}
+		}
+	}
+
+	shape := slices.WithLen[int](len(t.shape) - 1)
+	stride := slices.WithLen[int](len(t.stride) - 1)
+
+	copy(shape[:axis], t.shape[:axis])
+	copy(shape[axis:], t.shape[axis+1:])
+	copy(stride[:axis], t.stride[:axis])
+	copy(stride[axis:], t.stride[axis+1:])
+
+	views := make([]Tensor[T], t.shape[axis])
+	for i := range views {
+		views[i] = Tensor[T]{
+			data:   t.data,
+			shape:  slices.Clone(shape),
+			stride: slices.Clone(stride),
+			offset: t.offset + i*t.stride[axis],
+			eng:    t.eng,
+		}
+	}
+
+	return views
+}
+
+// PadMode is the way Pad fills the padded elements.
+type PadMode int
+
+const (
+	// PadConstant fills the padded elements with the given value.
+	PadConstant PadMode = iota
+
+	// PadEdge replicates the elements at the edges of each axis.
+	PadEdge
+
+	// PadReflect reflects each axis about its edge elements,
+	// without repeating them, so that [1, 2, 3] is padded
+	// by 2 on each side into [3, 2, 1, 2, 3, 2, 1].
+	PadReflect
+
+	// PadSymmetric reflects each axis about its edges,
+	// repeating the edge elements, so that [1, 2, 3] is
+	// padded by 2 on each side into [2, 1, 1, 2, 3, 3, 2].
+	PadSymmetric
+
+	// PadWrap wraps each axis around, so that [1, 2, 3] is
+	// padded by 2 on each side into [2, 3, 1, 2, 3, 1, 2].
+	PadWrap
+)
+
+// padIndex returns the index of the element of an axis of the given
+// dimensions that the given index, which might be out of the axis'
+// bounds, is padded with, or -1 if it's padded with the constant value.
+func padIndex(i, n int, mode PadMode) int {
+	if i >= 0 && i < n {
+		return i
+	}
+
+	// mod returns the non-negative remainder of i by m
+	mod := func(i, m int) int {
+		return (i%m + m) % m
+	}
+
+	switch mode {
+	case PadEdge:
+		if i < 0 {
+			return 0
+		}
+		return n - 1
+	case PadReflect:
+		if n == 1 {
+			return 0
+		}
+		i = mod(i, 2*(n-1))
+		if i >= n {
+			i = 2*(n-1) - i
+		}
+		return i
+	case PadSymmetric:
+		i = mod(i, 2*n)
+		if i >= n {
+			i = 2*n - 1 - i
+		}
+		return i
+	case PadWrap:
+		return mod(i, n)
+	default:
+		return -1
+	}
+}
+
+// Pad returns a copy of the Tensor padded along each axis by the given
+// number of elements before and after the axis, one pair per axis,
+// where the padded elements are filled according to the given mode.
+// The value is only used by PadConstant. The padding might be wider
+// than the axis, in which case the modes other than PadEdge repeat.
+func (t Tensor[T]) Pad(widths [][2]int, mode PadMode, value T) Tensor[T] {
+	if t.Err != nil {
+		if t.env().Interactive {
+			panic(t.Err)
+		} else {
+			return t
+		}
+	}
+
+	var err error
+	if len(widths) != len(t.shape) {
+		err = ErrShapeMismatch
+	} else if mode < PadConstant || mode > PadWrap {
+		err = ErrBadMode
+	}
+	for _, w := range widths {
+		if err == nil && (w[0] < 0 || w[1] < 0) {
+			err = ErrBadShape
+		}
+	}
+
+	if err != nil {
+		err = newOpError("Pad", err, -1, t.shape)
+		if t.env().Interactive {
+			panic(err)
+		} else {
+			t.Err = err
+			return t
+		}
+	}
+
+	if len(t.shape) == 0 {
+		return t.Clone()
+	}
+
+	shape := slices.WithLen[int](len(t.shape))
+	for i, w := range widths {
+		shape[i] = w[0] + t.shape[i] + w[1]
+	}
+
+	// the indices of the elements each axis is padded with,
+	// or -1 where it's padded with the constant value
+	tables := make([][]int, len(shape))
+	for i := range tables {
+		tables[i] = slices.WithLen[int](shape[i])
+		for j := range tables[i] {
+			tables[i][j] = padIndex(j-widths[i][0], t.shape[i], mode)
+		}
+	}
+
+	last := len(shape) - 1
+	n := shape[last]
+	out := slices.WithLen[T](slices.Prod(shape))
+
+	parallelize(len(out)/n, t.env().configCPU(len(out)), func(_, min, max int) {
+		for r := min; r < max; r++ {
+			row := out[r*n : (r+1)*n]
+
+			// unravel the row over all axes but the last
+			pos, q, fill := t.offset, r, false
+			for k := last - 1; k >= 0; k-- {
+				idx := tables[k][q%shape[k]]
+				if idx < 0 {
+					fill = true
+					break
+				}
+				pos += idx * t.stride[k]
+				q /= shape[k]
+			}
+
+			if fill {
+				for j := range row {
+					row[j] = value
+				}
+				continue
+			}
+
+			for j, idx := range tables[last] {
+				if idx < 0 {
+					row[j] = value
+				} else {
+					row[j] = t.data[pos+idx*t.stride[last]]
+				}
+			}
+		}
+	})
+
+	return Tensor[T]{
+		data:   out,
+		shape:  shape,
+		stride: configStride(shape),
+		eng:    t.eng,
+	}
+}
+
+// Tile repeats the Tensor along each axis the given number of times,
+// one per axis. If fewer repetitions than axes are given, the leading
+// axes aren't repeated, and if more are given, the Tensor's shape
+// is first prepended with axes of dimensions 1.
+func (t Tensor[T]) Tile(reps ...int) Tensor[T] {
+	if t.Err != nil {
+		if t.env().Interactive {
+			panic(t.Err)
+		} else {
+			return t
+		}
+	}
+
+	err := verifyGoodShape(reps...)
+	if err != nil {
+		err = newOpError("Tile", err, -1, t.shape, reps)
+		if t.env().Interactive {
+			panic(err)
+		} else {
+			t.Err = err
+			return t
+		}
+	}
+
+	rank := len(reps)
+	if len(t.shape) > rank {
+		rank = len(t.shape)
+	}
+
+	// the view holds a broadcast axis of each repetition's
+	// dimensions before each of the Tensor's axes
+	vshape := slices.WithLen[int](2 * rank)
+	vstride := slices.WithLen[int](2 * rank)
+	shape := slices.WithLen[int](rank)
+
+	for i := 0; i < rank; i++ {
+		r, d, s := 1, 1, 0
+		if j := i - (rank - len(reps)); j >= 0 {
+			r = reps[j]
+		}
+		if j := i - (rank - len(t.shape)); j >= 0 {
+			d, s = t.shape[j], t.stride[j]
+		}
+
+		vshape[2*i], vshape[2*i+1] = r, d
+		vstride[2*i+1] = s
+		shape[i] = r * d
+	}
+
+	view := Tensor[T]{
+		data:   t.data,
+		shape:  vshape,
+		stride: vstride,
+		offset: t.offset,
+		eng:    t.eng,
+	}
+
+	return view.Clone().Reshape(shape...)
+}
+
+// RepeatInterleave repeats each element of the Tensor
+// n times in a row along the given axis.
+func (t Tensor[T]) RepeatInterleave(n, axis int) Tensor[T] {
+	if t.Err != nil {
+		if t.env().Interactive {
+			panic(t.Err)
+		} else {
+			return t
+		}
+	}
+
+	err := verifyAxisBounds(axis, len(t.shape)-1)
+	if err == nil {
+		err = verifyGoodShape(n)
+	}
+	if err != nil {
+		err = newOpError("RepeatInterleave", err, axis, t.shape)
+		if t.env().Interactive {
+			panic(err)
+		} else {
+			t.Err = err
+			return t
+		}
+	}
+
+	// the view holds a broadcast axis of n dimensions after the axis
+	vshape := slices.WithLen[int](len(t.shape) + 1)
+	vstride := slices.WithLen[int](len(t.shape) + 1)
+
+	copy(vshape, t.shape[:axis+1])
+	copy(vstride, t.stride[:axis+1])
+	vshape[axis+1] = n
+	copy(vshape[axis+2:], t.shape[axis+1:])
+	copy(vstride[axis+2:], t.stride[axis+1:])
+
+	view := Tensor[T]{
+		data:   t.data,
+		shape:  vshape,
+		stride: vstride,
+		offset: t.offset,
+		eng:    t.eng,
+	}
+
+	shape := slices.Clone(t.shape)
+	shape[axis] *= n
+
+	return view.Clone().Reshape(shape...)
+}
+
+// Roll shifts the elements of the Tensor circularly along the given
+// axis by the given number of positions, where the elements shifted
+// past the end of the axis come back at its beginning, and a negative
+// shift shifts the elements backwards.
+func (t Tensor[T]) Roll(shift, axis int) Tensor[T] {
+	if t.Err != nil {
+		if t.env().Interactive {
+			panic(t.Err)
+		} else {
+			return t
+		}
+	}
+
+	err := verifyAxisBounds(axis, len(t.shape)-1)
+	if err != nil {
+		err = newOpError("Roll", err, axis, t.shape)
+		if t.env().Interactive {
+			panic(err)
+		} else {
+			t.Err = err
+			return t
+		}
+	}
+
+	n := t.shape[axis]
+	shift = (shift%n + n) % n
+	if shift == 0 {
+		return t.Clone()
+	}
+
+	views := t.Split([]int{n - shift, shift}, axis)
+
+	return Concat(axis, views[1], views[0])
+}
+
+// flipped returns a view of the Tensor walking
+// over the given axis backwards.
+func (t Tensor[T]) flipped(axis int) Tensor[T] {
+	stride := slices.Clone(t.stride)
+	stride[axis] = -t.stride[axis]
+
+	return Tensor[T]{
+		data:   t.data,
+		shape:  slices.Clone(t.shape),
+		stride: stride,
+		offset: t.offset + (t.shape[axis]-1)*t.stride[axis],
+		eng:    t.eng,
+	}
+}
+
+// swapped returns a view of the Tensor with the given axes swapped.
+func (t Tensor[T]) swapped(a, b int) Tensor[T] {
+	shape, stride := slices.Clone(t.shape), slices.Clone(t.stride)
+	shape[a], shape[b] = shape[b], shape[a]
+	stride[a], stride[b] = stride[b], stride[a]
+
+	return Tensor[T]{
+		data:   t.data,
+		shape:  shape,
+		stride: stride,
+		offset: t.offset,
+		eng:    t.eng,
+	}
+}
+
+// Rot90 returns a view of the Tensor rotated by 90 degrees k times in
+// the plane of the given axes, from the first axis towards the second,
+// as with NumPy's rot90. A negative k rotates the other way around.
+func (t Tensor[T]) Rot90(k int, axes [2]int) Tensor[T] {
+	if t.Err != nil {
+		if t.env().Interactive {
+			panic(t.Err)
+		} else {
+			return t
+		}
+	}
+
+	for i, axis := range axes {
+		err := verifyAxisBounds(axis, len(t.shape)-1)
+		if err == nil && i == 1 && axis == axes[0] {
+			err = ErrAxisBounds
+		}
+		if err != nil {
+			err = newOpError("Rot90", err, axis, t.shape)
+			if t.env().Interactive {
+				panic(err)
+			} else {
+				t.Err = err
+				return t
+			}
+		}
+	}
+
+	switch (k%4 + 4) % 4 {
+	case 1:
+		return t.flipped(axes[1]).swapped(axes[0], axes[1])
+	case 2:
+		return t.flipped(axes[0]).flipped(axes[1])
+	case 3:
+		return t.swapped(axes[0], axes[1]).flipped(axes[1])
+	default:
+		return t
 	}
 }
@@ -5,25 +5,12 @@
 package nune_test
 
 import (
+	"errors"
 	"testing"
-	"time"
 
 	"github.com/vorduin/nune"
 )
 
-func benchmarkMicro(b *testing.B, f func()) {
-	b.ResetTimer()
-
-	start := time.Now()
-	for i := 0; i < b.N; i++ {
-		f()
-	}
-	execTime := time.Since(start)
-
-	b.ReportMetric(0, "ns/op")
-	b.ReportMetric((1e6*execTime.Seconds())/float64(b.N), "μs/op")
-}
-
 func BenchmarkCast1e6(b *testing.B) {
 	tensor := nune.Range[float64](0, 1e6, 1)
 
@@ -72,6 +59,14 @@ func BenchmarkBroadcast1e6(b *testing.B) {
 	})
 }
 
+func BenchmarkExpand1e6(b *testing.B) {
+	tensor := nune.Range[float64](0, 200, 1).Reshape(200, 1)
+
+	benchmarkMicro(b, func() {
+		tensor.Expand(10, 200, 500)
+	})
+}
+
 func BenchmarkReverse1e6(b *testing.B) {
 	tensor := nune.Range[float64](0, 1e6, 1)
 
@@ -120,6 +115,48 @@ func BenchmarkStack1e6(b *testing.B) {
 	})
 }
 
+func BenchmarkConcat1e6(b *testing.B) {
+	tensor := nune.Range[float64](0, 1e5, 1).Reshape(1, 200, 500)
+	batches := []nune.Tensor[float64]{tensor, tensor, tensor, tensor, tensor, tensor, tensor, tensor, tensor, tensor}
+
+	benchmarkMicro(b, func() {
+		nune.Concat(0, batches...)
+	})
+}
+
+func BenchmarkStackAll1e6(b *testing.B) {
+	tensor := nune.Range[float64](0, 1e5, 1).Reshape(200, 500)
+	batches := []nune.Tensor[float64]{tensor, tensor, tensor, tensor, tensor, tensor, tensor, tensor, tensor, tensor}
+
+	benchmarkMicro(b, func() {
+		nune.StackAll(0, batches...)
+	})
+}
+
+func BenchmarkSplit1e6(b *testing.B) {
+	tensor := nune.Range[float64](0, 1e6, 1).Reshape(10, 200, 500)
+
+	benchmarkMicro(b, func() {
+		tensor.Split([]int{50, 100, 50}, 1)
+	})
+}
+
+func BenchmarkChunk1e6(b *testing.B) {
+	tensor := nune.Range[float64](0, 1e6, 1).Reshape(10, 200, 500)
+
+	benchmarkMicro(b, func() {
+		tensor.Chunk(7, 2)
+	})
+}
+
+func BenchmarkUnbind1e6(b *testing.B) {
+	tensor := nune.Range[float64](0, 1e6, 1).Reshape(10, 200, 500)
+
+	benchmarkMicro(b, func() {
+		tensor.Unbind(0)
+	})
+}
+
 func BenchmarkSqueeze1e6(b *testing.B) {
 	tensor := nune.Range[float64](0, 1e6, 1).Reshape(10, 200, 500)
 
@@ -135,3 +172,145 @@ func BenchmarkUnsqueeze1e6(b *testing.B) {
 		tensor.Unsqueeze(1)
 	})
 }
+
+func BenchmarkPadConstant(b *testing.B) {
+	tensor := newTensor().Reshape(1e3, 1e4)
+
+	benchmarkOp(b, func() {
+		tensor.Pad([][2]int{{2, 2}, {2, 2}}, nune.PadConstant, 0)
+	})
+}
+
+func BenchmarkPadReflect(b *testing.B) {
+	tensor := newTensor().Reshape(1e3, 1e4)
+
+	benchmarkOp(b, func() {
+		tensor.Pad([][2]int{{2, 2}, {2, 2}}, nune.PadReflect, 0)
+	})
+}
+
+func BenchmarkTile(b *testing.B) {
+	tensor := newTensor().Reshape(1e3, 1e4).S(nune.RTo(250), nune.RTo(5000))
+
+	benchmarkOp(b, func() {
+		tensor.Tile(2, 2)
+	})
+}
+
+func BenchmarkRepeatInterleave(b *testing.B) {
+	tensor := newTensor().Reshape(1e3, 1e4).S(nune.All, nune.RTo(2500))
+
+	benchmarkOp(b, func() {
+		tensor.RepeatInterleave(4, 1)
+	})
+}
+
+func BenchmarkRoll(b *testing.B) {
+	tensor := newTensor().Reshape(1e3, 1e4)
+
+	benchmarkOp(b, func() {
+		tensor.Roll(123, 1)
+	})
+}
+
+func BenchmarkRot90(b *testing.B) {
+	tensor := newTensor().Reshape(1e3, 1e4)
+
+	benchmarkOp(b, func() {
+		tensor.Rot90(1, [2]int{0, 1}).Contiguous()
+	})
+}
+
+func TestCatPermuted(t *testing.T) {
+	// the permuted Tensor's axis of dimensions 1 has a non row-major stride
+	a := nune.Range[float64](0, 3, 1).Reshape(1, 3).Permute(1, 0)
+	b := nune.Range[float64](10, 13, 1).Reshape(3, 1)
+
+	c := a.Cat(b, 1)
+	if c.Err != nil {
+		t.Fatal(c.Err)
+	}
+
+	want := []float64{0, 10, 1, 11, 2, 12}
+	got := c.Ravel()
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("Cat: got %v, want %v", got, want)
+		}
+	}
+}
+
+func TestExpandReadOnly(t *testing.T) {
+	x := nune.Zeros[float64](3)
+
+	ops := map[string]func(v nune.Tensor[float64]) nune.Tensor[float64]{
+		"Add": func(v nune.Tensor[float64]) nune.Tensor[float64] {
+			return v.Add(1.0)
+		},
+		"Exp": func(v nune.Tensor[float64]) nune.Tensor[float64] {
+			return v.Exp()
+		},
+		"Assign": func(v nune.Tensor[float64]) nune.Tensor[float64] {
+			return v.Assign(1.0)
+		},
+		"MaskedFill": func(v nune.Tensor[float64]) nune.Tensor[float64] {
+			return v.MaskedFill(nune.Ones[byte](3), 1)
+		},
+	}
+
+	for name, op := range ops {
+		v := op(x.Expand(4, 3))
+		if !errors.Is(v.Err, nune.ErrReadOnly) {
+			t.Errorf("%s: got error %v, want %v", name, v.Err, nune.ErrReadOnly)
+		}
+	}
+
+	for _, e := range x.Ravel() {
+		if e != 0 {
+			t.Fatalf("the expanded Tensor was written to: %v", x.Ravel())
+		}
+	}
+
+	// an Expand view only holding axes of dimensions 1 can be written to
+	if v := x.Expand(1, 3).Add(1.0); v.Err != nil {
+		t.Fatal(v.Err)
+	}
+}
+
+func TestPadBadMode(t *testing.T) {
+	tensor := nune.Range[float64](0, 6, 1).Reshape(2, 3)
+	padded := tensor.Pad([][2]int{{1, 1}, {1, 1}}, nune.PadMode(42), 0)
+
+	var e *nune.OpError
+	if !errors.As(padded.Err, &e) || e.Op != "Pad" || !errors.Is(padded.Err, nune.ErrBadMode) {
+		t.Fatalf("Pad: got error %v, want a Pad OpError wrapping %v", padded.Err, nune.ErrBadMode)
+	}
+}
+
+func TestChunkUneven(t *testing.T) {
+	cases := []struct {
+		d, n  int
+		sizes []int
+	}{
+		{6, 4, []int{2, 2, 1, 1}},
+		{7, 3, []int{3, 2, 2}},
+		{5, 3, []int{2, 2, 1}},
+		{6, 3, []int{2, 2, 2}},
+	}
+
+	for _, c := range cases {
+		chunks := nune.Range[float64](0, c.d, 1).Chunk(c.n, 0)
+		if len(chunks) != len(c.sizes) {
+			t.Fatalf("Chunk(%d) of %d: got %d chunks, want %d", c.n, c.d, len(chunks), len(c.sizes))
+		}
+
+		for i, chunk := range chunks {
+			if chunk.Err != nil {
+				t.Fatal(chunk.Err)
+			}
+			if chunk.Size(0) != c.sizes[i] {
+				t.Errorf("Chunk(%d) of %d: chunk %d has size %d, want %d", c.n, c.d, i, chunk.Size(0), c.sizes[i])
+			}
+		}
+	}
+}
@@ -5,49 +5,69 @@
 package nune
 
 import (
-	"sync"
 	"math"
 )
 
-// handleMap processes a pointwise operation accordingly.
-func handleMap[T Number](in, out []T, f func(T) T, nCPU int) {
-	var wg sync.WaitGroup
-
-	for i := 0; i < nCPU; i++ {
-		min := (i * len(in) / nCPU)
-		max := ((i + 1) * len(in)) / nCPU
-
-		wg.Add(1)
-		go func(inBuf, outBuf []T) {
-			for j := 0; j < len(inBuf); j++ {
-				outBuf[j] = f(inBuf[j])
+// handleMap processes a pointwise operation accordingly,
+// walking over the Tensor's view in its data buffer.
+// The contiguous runs are processed by the operation's dedicated
+// kernel, if any, and by calling f otherwise.
+func handleMap[T Number](t Tensor[T], op kernelOp, f func(T) T, nCPU int) {
+	shape, strides := coalesce(t.shape, t.stride)
+
+	parallelize(t.Numel(), nCPU, func(_, min, max int) {
+		w := newWalker(shape, strides[0], t.offset, min)
+
+		for n := max - min; n > 0; {
+			pos, step, l := w.run(n)
+
+			if step == 1 {
+				buf := t.data[pos : pos+l]
+				if !mapKernel(op, buf) {
+					for j := 0; j < len(buf); j++ {
+						buf[j] = f(buf[j])
+					}
+				}
+			} else {
+				for j := 0; j < l; j++ {
+					t.data[pos] = f(t.data[pos])
+					pos += step
+				}
 			}
 
-			wg.Done()
-		}(in[min:max], out[min:max])
-	}
-
-	wg.Wait()
+			n -= l
+		}
+	})
 }
 
 // Map performs a pointwise operation over the elements of this Tensor.
 func (t Tensor[T]) Map(f func(T) T) Tensor[T] {
+	return t.mapWith(opNone, f)
+}
+
+// mapWith performs a pointwise operation over the elements of this Tensor,
+// using the operation's dedicated kernel where possible.
+func (t Tensor[T]) mapWith(op kernelOp, f func(T) T) Tensor[T] {
 	if t.Err != nil {
-		if EnvConfig.Interactive {
+		if t.env().Interactive {
 			panic(t.Err)
 		} else {
 			return t
 		}
 	}
 
-	handleMap(t.Ravel(), t.Ravel(), f, configCPU(t.Numel()))
+	if t, ok := t.writable("Map"); !ok {
+		return t
+	}
+
+	handleMap(t, op, f, t.env().configCPU(t.Numel()))
 
 	return t
 }
 
 // Abs computes the absolute value of each element in the Tensor.
 func (t Tensor[T]) Abs() Tensor[T] {
-	return t.Map(func(x T) T {
+	return t.mapWith(opAbs, func(x T) T {
 		return T(math.Abs(float64(x)))
 	})
 }
@@ -112,7 +132,7 @@ func (t Tensor[T]) Cbrt() Tensor[T] {
 // Ceil computes the least integer value great than or equal to x,
 // where x is each element in the Tensor.
 func (t Tensor[T]) Ceil() Tensor[T] {
-	return t.Map(func(x T) T {
+	return t.mapWith(opCeil, func(x T) T {
 		return T(math.Ceil(float64(x)))
 	})
 }
@@ -180,7 +200,7 @@ func (t Tensor[T]) Erfinv() Tensor[T] {
 
 // Exp computes the base-e exponential of each element in the Tensor.
 func (t Tensor[T]) Exp() Tensor[T] {
-	return t.Map(func(x T) T {
+	return t.mapWith(opExp, func(x T) T {
 		return T(math.Exp(float64(x)))
 	})
 }
@@ -212,7 +232,7 @@ func (t Tensor[T]) FMA(y, z float64) Tensor[T] {
 // Floor computes the greatest integer value less than or equal to
 // each element in the Tensor.
 func (t Tensor[T]) Floor() Tensor[T] {
-	return t.Map(func(x T) T {
+	return t.mapWith(opFloor, func(x T) T {
 		return T(math.Floor(float64(x)))
 	})
 }
@@ -390,7 +410,7 @@ func (t Tensor[T]) Sinh() Tensor[T] {
 
 // Sqrt computes the square root of each element in the Tensor.
 func (t Tensor[T]) Sqrt() Tensor[T] {
-	return t.Map(func(x T) T {
+	return t.mapWith(opSqrt, func(x T) T {
 		return T(math.Sqrt(float64(x)))
 	})
 }
@@ -411,7 +431,7 @@ func (t Tensor[T]) Tanh() Tensor[T] {
 
 // Trunc computes the integer value of each element in the Tensor.
 func (t Tensor[T]) Trunc() Tensor[T] {
-	return t.Map(func(x T) T {
+	return t.mapWith(opTrunc, func(x T) T {
 		return T(math.Trunc(float64(x)))
 	})
 }
@@ -0,0 +1,265 @@
+// Copyright © The Nune Author. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package nune
+
+import (
+	"github.com/vorduin/slices"
+)
+
+// The dimensions of the blocks the matrix products are split into,
+// chosen so that a block of the right-hand side matrix fits in cache.
+const (
+	blockRows  = 64
+	blockInner = 256
+	blockCols  = 512
+)
+
+// handleMatMul computes the matrix products of all batches of two views,
+// whose last two axes must be contiguous, and stores them in the output buffer.
+// The products are split into blocks of rows that are computed concurrently.
+func handleMatMul[T Number](lhs, rhs Tensor[T], out []T, batch []int, n, k, m, nCPU int) {
+	numBatches := 1
+	if len(batch) > 0 {
+		numBatches = slices.Prod(batch)
+	}
+	rowBlocks := (n + blockRows - 1) / blockRows
+
+	parallelize(numBatches*rowBlocks, nCPU, func(_, min, max int) {
+		for task := min; task < max; task++ {
+			b, rb := task/rowBlocks, task%rowBlocks
+
+			// unravel the batch index over both views
+			loff, roff, r := lhs.offset, rhs.offset, b
+			for i := len(batch) - 1; i >= 0; i-- {
+				loff += (r % batch[i]) * lhs.stride[i]
+				roff += (r % batch[i]) * rhs.stride[i]
+				r /= batch[i]
+			}
+
+			c := out[b*n*m : (b+1)*n*m]
+
+			i0, i1 := rb*blockRows, (rb+1)*blockRows
+			if i1 > n {
+				i1 = n
+			}
+
+			for p0 := 0; p0 < k; p0 += blockInner {
+				p1 := p0 + blockInner
+				if p1 > k {
+					p1 = k
+				}
+
+				for j0 := 0; j0 < m; j0 += blockCols {
+					j1 := j0 + blockCols
+					if j1 > m {
+						j1 = m
+					}
+
+					for i := i0; i < i1; i++ {
+						cRow := c[i*m+j0 : i*m+j1]
+						aRow := lhs.data[loff+i*k : loff+(i+1)*k]
+
+						for p := p0; p < p1; p++ {
+							a := aRow[p]
+							bRow := rhs.data[roff+p*m+j0 : roff+p*m+j1]
+							for j := 0; j < len(cRow); j++ {
+								cRow[j] += a * bRow[j]
+							}
+						}
+					}
+				}
+			}
+		}
+	})
+}
+
+// MatMul returns the matrix product of this and the other Tensor,
+// both of which must be at least rank 2. Tensors of higher ranks
+// are treated as batches of matrices held in their last two axes,
+// and their leading axes are broadcast together.
+func (t Tensor[T]) MatMul(other Tensor[T]) Tensor[T] {
+	if t.Err != nil {
+		if t.env().Interactive {
+			panic(t.Err)
+		} else {
+			return t
+		}
+	}
+
+	if other.Err != nil {
+		if t.env().Interactive {
+			panic(other.Err)
+		} else {
+			t.Err = other.Err
+			return t
+		}
+	}
+
+	if t.Rank() < 2 || other.Rank() < 2 {
+		err := newOpError("MatMul", ErrBadShape, -1, t.shape, other.shape)
+		if t.env().Interactive {
+			panic(err)
+		} else {
+			t.Err = err
+			return t
+		}
+	}
+
+	n, k := t.shape[t.Rank()-2], t.shape[t.Rank()-1]
+	m := other.shape[other.Rank()-1]
+
+	if other.shape[other.Rank()-2] != k {
+		err := newOpError("MatMul", ErrShapeMismatch, -1, t.shape, other.shape)
+		if t.env().Interactive {
+			panic(err)
+		} else {
+			t.Err = err
+			return t
+		}
+	}
+
+	batch, err := broadcastShapes(t.shape[:t.Rank()-2], other.shape[:other.Rank()-2])
+	if err != nil {
+		err = newOpError("MatMul", err, -1, t.shape, other.shape)
+		if t.env().Interactive {
+			panic(err)
+		} else {
+			t.Err = err
+			return t
+		}
+	}
+
+	shape := slices.WithLen[int](len(batch) + 2)
+	copy(shape, batch)
+
+	shape[len(batch)], shape[len(batch)+1] = n, k
+	lhs := t.Contiguous().Expand(shape...)
+
+	shape[len(batch)], shape[len(batch)+1] = k, m
+	rhs := other.Contiguous().Expand(shape...)
+
+	shape[len(batch)], shape[len(batch)+1] = n, m
+	out := slices.WithLen[T](slices.Prod(shape))
+
+	nCPU := t.env().configCPU(len(out) * k)
+	handleMatMul(lhs, rhs, out, batch, n, k, m, nCPU)
+
+	return Tensor[T]{
+		data:   out,
+		shape:  shape,
+		stride: configStride(shape),
+		eng:    t.eng,
+	}
+}
+
+// MatVec returns the product of this matrix, or batch of matrices,
+// and the other rank 1 Tensor.
+func (t Tensor[T]) MatVec(other Tensor[T]) Tensor[T] {
+	if t.Err != nil {
+		if t.env().Interactive {
+			panic(t.Err)
+		} else {
+			return t
+		}
+	}
+
+	if other.Rank() != 1 && other.Err == nil {
+		err := newOpError("MatVec", ErrBadShape, -1, t.shape, other.shape)
+		if t.env().Interactive {
+			panic(err)
+		} else {
+			t.Err = err
+			return t
+		}
+	}
+
+	t = t.MatMul(other.Unsqueeze(1))
+	if t.Err != nil {
+		return t
+	}
+
+	return t.Squeeze(t.Rank() - 1)
+}
+
+// Dot returns the dot product of this and the other rank 1 Tensor.
+func (t Tensor[T]) Dot(other Tensor[T]) Tensor[T] {
+	if t.Err != nil {
+		if t.env().Interactive {
+			panic(t.Err)
+		} else {
+			return t
+		}
+	}
+
+	if other.Err != nil {
+		if t.env().Interactive {
+			panic(other.Err)
+		} else {
+			t.Err = other.Err
+			return t
+		}
+	}
+
+	if t.Rank() != 1 || other.Rank() != 1 {
+		err := newOpError("Dot", ErrBadShape, -1, t.shape, other.shape)
+		if t.env().Interactive {
+			panic(err)
+		} else {
+			t.Err = err
+			return t
+		}
+	}
+
+	if t.shape[0] != other.shape[0] {
+		err := newOpError("Dot", ErrShapeMismatch, -1, t.shape, other.shape)
+		if t.env().Interactive {
+			panic(err)
+		} else {
+			t.Err = err
+			return t
+		}
+	}
+
+	lhs, rhs := t.Contiguous().Ravel(), other.Contiguous().Ravel()
+
+	nCPU := t.env().configCPU(len(lhs))
+	outBuf := slices.WithLen[T](nCPU)
+
+	parallelize(len(lhs), nCPU, func(i, min, max int) {
+		var sum T
+		for j := min; j < max; j++ {
+			sum += lhs[j] * rhs[j]
+		}
+		outBuf[i] = sum
+	})
+
+	return Tensor[T]{
+		data: []T{reduceSum(outBuf)},
+		eng:  t.eng,
+	}
+}
+
+// Outer returns the outer product of this and the other rank 1 Tensor.
+func (t Tensor[T]) Outer(other Tensor[T]) Tensor[T] {
+	if t.Err != nil {
+		if t.env().Interactive {
+			panic(t.Err)
+		} else {
+			return t
+		}
+	}
+
+	if (t.Rank() != 1 || other.Rank() != 1) && other.Err == nil {
+		err := newOpError("Outer", ErrBadShape, -1, t.shape, other.shape)
+		if t.env().Interactive {
+			panic(err)
+		} else {
+			t.Err = err
+			return t
+		}
+	}
+
+	return t.Unsqueeze(1).MatMul(other.Unsqueeze(0))
+}
@@ -0,0 +1,44 @@
+// Copyright © The Nune Author. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package nune_test
+
+import (
+	"testing"
+
+	"github.com/vorduin/nune"
+)
+
+func BenchmarkMatMul(b *testing.B) {
+	tensor := nune.Range[float64](0, 1e6, 1).Reshape(1e3, 1e3)
+
+	benchmarkOp(b, func() {
+		tensor.MatMul(tensor)
+	})
+}
+
+func BenchmarkMatMulBatched(b *testing.B) {
+	tensor := nune.Range[float64](0, 1e6, 1).Reshape(16, 250, 250)
+
+	benchmarkOp(b, func() {
+		tensor.MatMul(tensor)
+	})
+}
+
+func BenchmarkMatVec(b *testing.B) {
+	tensor := newTensor().Reshape(1e4, 1e3)
+	vector := nune.Range[float64](0, 1e3, 1)
+
+	benchmarkOp(b, func() {
+		tensor.MatVec(vector)
+	})
+}
+
+func BenchmarkDot(b *testing.B) {
+	tensor := newTensor()
+
+	benchmarkOp(b, func() {
+		tensor.Dot(tensor)
+	})
+}
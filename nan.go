@@ -0,0 +1,293 @@
+// Copyright © The Nune Author. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package nune
+
+import (
+	"math"
+
+	"github.com/vorduin/slices"
+)
+
+// reduceNanSum returns the sum of a slice, ignoring NaNs.
+func reduceNanSum[T Number](s []T) T {
+	var sum T
+	for _, x := range s {
+		if !isNaN(x) {
+			sum += x
+		}
+	}
+	return sum
+}
+
+// reduceNanMin returns the minimum value of a slice, ignoring NaNs,
+// or a NaN if the slice only holds NaNs.
+func reduceNanMin[T Number](s []T) T {
+	min := s[0]
+	for _, x := range s[1:] {
+		if x < min || isNaN(min) {
+			min = x
+		}
+	}
+	return min
+}
+
+// reduceNanMax returns the maximum value of a slice, ignoring NaNs,
+// or a NaN if the slice only holds NaNs.
+func reduceNanMax[T Number](s []T) T {
+	max := s[0]
+	for _, x := range s[1:] {
+		if x > max || isNaN(max) {
+			max = x
+		}
+	}
+	return max
+}
+
+// nanWelford returns the moments of a slice, ignoring NaNs.
+func nanWelford[T Number](s []T) moments {
+	var m moments
+	for _, x := range s {
+		if isNaN(x) {
+			continue
+		}
+
+		m.n++
+		d := float64(x) - m.mean
+		m.mean += d / m.n
+		m.m2 += d * (float64(x) - m.mean)
+	}
+
+	return m
+}
+
+// nanMoments returns the moments of all elements in the Tensor, ignoring
+// NaNs, where each share of the elements is processed concurrently.
+func (t Tensor[T]) nanMoments() moments {
+	n := t.env().configShares(t.Numel())
+	shares := make([]moments, n)
+
+	handleShares(t, func(i int, s []T) {
+		shares[i] = nanWelford(s)
+	}, n)
+
+	var m moments
+	for _, share := range shares {
+		m = m.merge(share)
+	}
+
+	return m
+}
+
+// nanStd returns the standard deviation of the given moments,
+// with the given delta degrees of freedom, which is a NaN
+// if there are no degrees of freedom left.
+func nanStd(m moments, ddof int) float64 {
+	if m.n-float64(ddof) <= 0 {
+		return math.NaN()
+	}
+
+	return math.Sqrt(m.m2 / (m.n - float64(ddof)))
+}
+
+// NanSum returns the sum of all elements in the Tensor, ignoring NaNs.
+func (t Tensor[T]) NanSum() Tensor[T] {
+	return t.Reduce(reduceNanSum[T])
+}
+
+// NanMin returns the minimum value of all elements in the Tensor,
+// ignoring NaNs, or a NaN if all the elements are NaNs.
+func (t Tensor[T]) NanMin() Tensor[T] {
+	return t.Reduce(reduceNanMin[T])
+}
+
+// NanMax returns the maximum value of all elements in the Tensor,
+// ignoring NaNs, or a NaN if all the elements are NaNs.
+func (t Tensor[T]) NanMax() Tensor[T] {
+	return t.Reduce(reduceNanMax[T])
+}
+
+// NanMean returns the mean value of all elements in the Tensor,
+// ignoring NaNs, or a NaN if all the elements are NaNs.
+// The sums of the shares of the elements are accumulated in float64.
+func (t Tensor[T]) NanMean() Tensor[T] {
+	if t.Err != nil {
+		if t.env().Interactive {
+			panic(t.Err)
+		} else {
+			return t
+		}
+	}
+
+	shares := t.env().configShares(t.Numel())
+	sums := slices.WithLen[float64](shares)
+	counts := slices.WithLen[int](shares)
+
+	handleShares(t, func(i int, s []T) {
+		for _, x := range s {
+			if !isNaN(x) {
+				sums[i] += float64(x)
+				counts[i]++
+			}
+		}
+	}, shares)
+
+	var sum float64
+	var n int
+	for i := range sums {
+		sum += sums[i]
+		n += counts[i]
+	}
+
+	return Tensor[T]{
+		data: []T{T(sum / float64(n))},
+		eng:  t.eng,
+	}
+}
+
+// NanStd returns the standard deviation of all elements in the Tensor,
+// ignoring NaNs, with the given delta degrees of freedom. It's a NaN
+// if the number of elements that aren't NaNs is at most ddof.
+func (t Tensor[T]) NanStd(ddof int) Tensor[T] {
+	if t.Err != nil {
+		if t.env().Interactive {
+			panic(t.Err)
+		} else {
+			return t
+		}
+	}
+
+	if t, ok := t.verifyDdof("NanStd", ddof, math.MaxInt, -1); !ok {
+		return t
+	}
+
+	return Tensor[T]{
+		data: []T{T(nanStd(t.nanMoments(), ddof))},
+		eng:  t.eng,
+	}
+}
+
+// NanSumAxis returns the sum of the elements along
+// the given axis of the Tensor, ignoring NaNs.
+func (t Tensor[T]) NanSumAxis(axis int, keepDims bool) Tensor[T] {
+	return t.ReduceAxis(axis, keepDims, reduceNanSum[T])
+}
+
+// NanMinAxis returns the minimum value of the elements along
+// the given axis of the Tensor, ignoring NaNs.
+func (t Tensor[T]) NanMinAxis(axis int, keepDims bool) Tensor[T] {
+	return t.ReduceAxis(axis, keepDims, reduceNanMin[T])
+}
+
+// NanMaxAxis returns the maximum value of the elements along
+// the given axis of the Tensor, ignoring NaNs.
+func (t Tensor[T]) NanMaxAxis(axis int, keepDims bool) Tensor[T] {
+	return t.ReduceAxis(axis, keepDims, reduceNanMax[T])
+}
+
+// NanMeanAxis returns the mean value of the elements along
+// the given axis of the Tensor, ignoring NaNs.
+func (t Tensor[T]) NanMeanAxis(axis int, keepDims bool) Tensor[T] {
+	return t.ReduceAxis(axis, keepDims, func(s []T) T {
+		m := nanWelford(s)
+		if m.n == 0 {
+			return T(math.NaN())
+		}
+		return T(m.mean)
+	})
+}
+
+// NanStdAxis returns the standard deviation of the elements along the
+// given axis of the Tensor, ignoring NaNs, with the given delta
+// degrees of freedom.
+func (t Tensor[T]) NanStdAxis(axis, ddof int, keepDims bool) Tensor[T] {
+	if t.Err != nil {
+		if t.env().Interactive {
+			panic(t.Err)
+		} else {
+			return t
+		}
+	}
+
+	if t, ok := t.verifyDdof("NanStdAxis", ddof, math.MaxInt, axis); !ok {
+		return t
+	}
+
+	return t.ReduceAxis(axis, keepDims, func(s []T) T {
+		return T(nanStd(nanWelford(s), ddof))
+	})
+}
+
+// mask returns a mask holding 1 where the predicate
+// holds for the Tensor's elements, and 0 elsewhere.
+func (t Tensor[T]) mask(f func(x float64) bool) Tensor[byte] {
+	if t.Err != nil {
+		if t.env().Interactive {
+			panic(t.Err)
+		} else {
+			return Tensor[byte]{
+				Err: t.Err,
+			}
+		}
+	}
+
+	out := slices.WithLen[byte](t.Numel())
+
+	offsets := []int{t.offset}
+	strides := [][]int{t.stride}
+
+	handleViews(t.shape, offsets, strides, t.env().configCPU(len(out)), func(i int, pos, step []int, l int) {
+		for j := 0; j < l; j++ {
+			if f(float64(t.data[pos[0]])) {
+				out[i+j] = 1
+			}
+			pos[0] += step[0]
+		}
+	})
+
+	return Tensor[byte]{
+		data:   out,
+		shape:  slices.Clone(t.shape),
+		stride: configStride(t.shape),
+		eng:    t.eng,
+	}
+}
+
+// IsNaN returns a mask of where the Tensor's elements are NaNs.
+func (t Tensor[T]) IsNaN() Tensor[byte] {
+	return t.mask(math.IsNaN)
+}
+
+// IsInf returns a mask of where the Tensor's elements
+// are positive or negative infinities.
+func (t Tensor[T]) IsInf() Tensor[byte] {
+	return t.mask(func(x float64) bool {
+		return math.IsInf(x, 0)
+	})
+}
+
+// IsFinite returns a mask of where the Tensor's elements
+// are neither NaNs nor infinities.
+func (t Tensor[T]) IsFinite() Tensor[byte] {
+	return t.mask(func(x float64) bool {
+		return !math.IsNaN(x) && !math.IsInf(x, 0)
+	})
+}
+
+// NanToNum replaces the NaNs, positive infinities and negative
+// infinities among the Tensor's elements with the given values.
+func (t Tensor[T]) NanToNum(nan, posInf, negInf float64) Tensor[T] {
+	return t.Map(func(x T) T {
+		switch v := float64(x); {
+		case math.IsNaN(v):
+			return T(nan)
+		case math.IsInf(v, 1):
+			return T(posInf)
+		case math.IsInf(v, -1):
+			return T(negInf)
+		default:
+			return x
+		}
+	})
+}
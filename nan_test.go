@@ -0,0 +1,33 @@
+// Copyright © The Nune Author. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package nune_test
+
+import (
+	"testing"
+)
+
+func BenchmarkNanSum(b *testing.B) {
+	tensor := newTensor()
+
+	benchmarkOp(b, func() {
+		tensor.NanSum()
+	})
+}
+
+func BenchmarkNanMean(b *testing.B) {
+	tensor := newTensor()
+
+	benchmarkOp(b, func() {
+		tensor.NanMean()
+	})
+}
+
+func BenchmarkIsNaN(b *testing.B) {
+	tensor := newTensor()
+
+	benchmarkOp(b, func() {
+		tensor.IsNaN()
+	})
+}
@@ -0,0 +1,442 @@
+// Copyright © The Nune Author. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package nune
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"math"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/vorduin/slices"
+)
+
+// npyMagic is the magic string every .npy file starts with.
+const npyMagic = "\x93NUMPY"
+
+// An npyHeader holds the description of the data of a .npy file.
+type npyHeader struct {
+	order   binary.ByteOrder // the byte order of the elements
+	kind    byte             // the kind of the elements, 'i', 'u', 'f' or 'b'
+	size    int              // the size in bytes of the elements
+	fortran bool             // whether the data is in column-major order
+	shape   []int            // the shape of the array
+}
+
+// npyDescr returns the .npy type description of a numeric type,
+// where all multi-byte types are little-endian.
+func npyDescr[T Number]() string {
+	var x T
+	size := int(reflect.TypeOf(x).Size())
+
+	var kind byte
+	switch reflect.ValueOf(x).Kind() {
+	case reflect.Float32, reflect.Float64:
+		kind = 'f'
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		kind = 'u'
+	default:
+		kind = 'i'
+	}
+
+	if size == 1 {
+		return fmt.Sprintf("|%c1", kind)
+	}
+	return fmt.Sprintf("<%c%d", kind, size)
+}
+
+// encodeNpy encodes the elements as little-endian bytes.
+func encodeNpy[T Number](data []T) []byte {
+	var x T
+	size := int(reflect.TypeOf(x).Size())
+	kind := reflect.ValueOf(x).Kind()
+
+	buf := make([]byte, len(data)*size)
+	order := binary.LittleEndian
+
+	for i, x := range data {
+		b := buf[i*size : (i+1)*size]
+
+		switch {
+		case kind == reflect.Float32:
+			order.PutUint32(b, math.Float32bits(float32(x)))
+		case kind == reflect.Float64:
+			order.PutUint64(b, math.Float64bits(float64(x)))
+		case size == 1:
+			b[0] = byte(x)
+		case size == 2:
+			order.PutUint16(b, uint16(x))
+		case size == 4:
+			order.PutUint32(b, uint32(x))
+		default:
+			order.PutUint64(b, uint64(x))
+		}
+	}
+
+	return buf
+}
+
+// decodeNpy decodes the bytes into the given buffer
+// according to the .npy header's type description.
+func decodeNpy[T Number](buf []byte, h npyHeader, data []T) error {
+	for i := range data {
+		b := buf[i*h.size : (i+1)*h.size]
+
+		switch {
+		case h.kind == 'f' && h.size == 4:
+			data[i] = T(math.Float32frombits(h.order.Uint32(b)))
+		case h.kind == 'f' && h.size == 8:
+			data[i] = T(math.Float64frombits(h.order.Uint64(b)))
+		case h.kind == 'i' && h.size == 1:
+			data[i] = T(int8(b[0]))
+		case h.kind == 'i' && h.size == 2:
+			data[i] = T(int16(h.order.Uint16(b)))
+		case h.kind == 'i' && h.size == 4:
+			data[i] = T(int32(h.order.Uint32(b)))
+		case h.kind == 'i' && h.size == 8:
+			data[i] = T(int64(h.order.Uint64(b)))
+		case (h.kind == 'u' || h.kind == 'b') && h.size == 1:
+			data[i] = T(b[0])
+		case h.kind == 'u' && h.size == 2:
+			data[i] = T(h.order.Uint16(b))
+		case h.kind == 'u' && h.size == 4:
+			data[i] = T(h.order.Uint32(b))
+		case h.kind == 'u' && h.size == 8:
+			data[i] = T(h.order.Uint64(b))
+		default:
+			return ErrBadNpy
+		}
+	}
+
+	return nil
+}
+
+// npyValue returns the raw value of the given key
+// in a .npy header's dictionary literal.
+func npyValue(dict, key string) (string, bool) {
+	i := strings.Index(dict, "'"+key+"'")
+	if i < 0 {
+		return "", false
+	}
+
+	v := strings.TrimSpace(dict[i+len(key)+2:])
+	if !strings.HasPrefix(v, ":") {
+		return "", false
+	}
+	v = strings.TrimSpace(v[1:])
+
+	switch {
+	case strings.HasPrefix(v, "'"):
+		end := strings.Index(v[1:], "'")
+		if end < 0 {
+			return "", false
+		}
+		return v[1 : end+1], true
+	case strings.HasPrefix(v, "("):
+		end := strings.Index(v, ")")
+		if end < 0 {
+			return "", false
+		}
+		return v[1:end], true
+	default:
+		end := strings.IndexAny(v, ",}")
+		if end < 0 {
+			return "", false
+		}
+		return strings.TrimSpace(v[:end]), true
+	}
+}
+
+// parseNpyHeader parses a .npy header's dictionary literal.
+func parseNpyHeader(dict string) (npyHeader, error) {
+	var h npyHeader
+
+	descr, ok := npyValue(dict, "descr")
+	if !ok || len(descr) < 3 {
+		return h, ErrBadNpy
+	}
+
+	switch descr[0] {
+	case '<', '|', '=':
+		h.order = binary.LittleEndian
+	case '>':
+		h.order = binary.BigEndian
+	default:
+		return h, ErrBadNpy
+	}
+
+	h.kind = descr[1]
+
+	size, err := strconv.Atoi(descr[2:])
+	if err != nil || (size != 1 && size != 2 && size != 4 && size != 8) {
+		return h, ErrBadNpy
+	}
+	h.size = size
+
+	fortran, ok := npyValue(dict, "fortran_order")
+	if !ok || (fortran != "True" && fortran != "False") {
+		return h, ErrBadNpy
+	}
+	h.fortran = fortran == "True"
+
+	shape, ok := npyValue(dict, "shape")
+	if !ok {
+		return h, ErrBadNpy
+	}
+
+	for _, s := range strings.Split(shape, ",") {
+		if s = strings.TrimSpace(s); s == "" {
+			continue
+		}
+
+		d, err := strconv.Atoi(s)
+		if err != nil || d <= 0 {
+			return h, ErrBadNpy
+		}
+		h.shape = append(h.shape, d)
+	}
+
+	return h, nil
+}
+
+// WriteNpy writes the Tensor to the writer in the NumPy .npy
+// format, as a little-endian array in row-major order.
+func WriteNpy[T Number](w io.Writer, t Tensor[T]) error {
+	if t.Err != nil {
+		return t.Err
+	}
+
+	shape := make([]string, len(t.shape))
+	for i, d := range t.shape {
+		shape[i] = strconv.Itoa(d)
+	}
+
+	tuple := strings.Join(shape, ", ")
+	if len(shape) == 1 {
+		tuple += ","
+	}
+
+	dict := fmt.Sprintf("{'descr': '%s', 'fortran_order': False, 'shape': (%s), }", npyDescr[T](), tuple)
+
+	// the header is padded with spaces and a newline
+	// so that the data is aligned on 64 bytes
+	version, lenSize := byte(1), 2
+	if len(dict)+11 > math.MaxUint16 {
+		version, lenSize = 2, 4
+	}
+
+	prefix := len(npyMagic) + 2 + lenSize
+	pad := 64 - (prefix+len(dict)+1)%64
+	if pad == 64 {
+		pad = 0
+	}
+	dict += strings.Repeat(" ", pad) + "\n"
+
+	var header bytes.Buffer
+	header.WriteString(npyMagic)
+	header.Write([]byte{version, 0})
+	if version == 1 {
+		binary.Write(&header, binary.LittleEndian, uint16(len(dict)))
+	} else {
+		binary.Write(&header, binary.LittleEndian, uint32(len(dict)))
+	}
+	header.WriteString(dict)
+
+	if _, err := w.Write(header.Bytes()); err != nil {
+		return err
+	}
+
+	_, err := w.Write(encodeNpy(t.Contiguous().Ravel()))
+
+	return err
+}
+
+// ReadNpy reads a Tensor in the NumPy .npy format from the reader,
+// casting its elements to the given numeric type. Both byte orders
+// and both row-major and column-major arrays are supported.
+func ReadNpy[T Number](r io.Reader) Tensor[T] {
+	t, err := readNpy[T](r)
+	if err != nil {
+		if EnvConfig.Interactive {
+			panic(err)
+		} else {
+			return Tensor[T]{
+				Err: err,
+			}
+		}
+	}
+
+	return t
+}
+
+// readNpy reads a Tensor in the NumPy .npy format from the reader.
+func readNpy[T Number](r io.Reader) (Tensor[T], error) {
+	prefix := make([]byte, len(npyMagic)+2)
+	if _, err := io.ReadFull(r, prefix); err != nil {
+		return Tensor[T]{}, err
+	}
+
+	if string(prefix[:len(npyMagic)]) != npyMagic {
+		return Tensor[T]{}, ErrBadNpy
+	}
+
+	var headerLen int
+	switch prefix[len(npyMagic)] {
+	case 1:
+		var l uint16
+		if err := binary.Read(r, binary.LittleEndian, &l); err != nil {
+			return Tensor[T]{}, err
+		}
+		headerLen = int(l)
+	case 2, 3:
+		var l uint32
+		if err := binary.Read(r, binary.LittleEndian, &l); err != nil {
+			return Tensor[T]{}, err
+		}
+		headerLen = int(l)
+	default:
+		return Tensor[T]{}, ErrBadNpy
+	}
+
+	dict := make([]byte, headerLen)
+	if _, err := io.ReadFull(r, dict); err != nil {
+		return Tensor[T]{}, err
+	}
+
+	h, err := parseNpyHeader(string(dict))
+	if err != nil {
+		return Tensor[T]{}, err
+	}
+
+	// the number of bytes of the data must not overflow
+	numel := 1
+	for _, d := range h.shape {
+		if d < 0 || (d > 0 && numel > math.MaxInt/h.size/d) {
+			return Tensor[T]{}, newOpError("ReadNpy", ErrBadNpy, -1, h.shape)
+		}
+		numel *= d
+	}
+
+	// the data is read as it comes, rather than in a buffer allocated
+	// up front, so that a header announcing more data than the reader
+	// holds doesn't allocate for it
+	var b bytes.Buffer
+	n, err := io.CopyN(&b, r, int64(numel*h.size))
+	if err != nil {
+		if err == io.EOF && n > 0 {
+			err = io.ErrUnexpectedEOF
+		}
+		return Tensor[T]{}, err
+	}
+	buf := b.Bytes()
+
+	data := slices.WithLen[T](numel)
+	if err := decodeNpy(buf, h, data); err != nil {
+		return Tensor[T]{}, err
+	}
+
+	if len(h.shape) == 0 {
+		return Tensor[T]{
+			data: data,
+		}, nil
+	}
+
+	if !h.fortran {
+		return Tensor[T]{
+			data:   data,
+			shape:  h.shape,
+			stride: configStride(h.shape),
+		}, nil
+	}
+
+	// a column-major array is a row-major array of the reversed shape,
+	// viewed with its axes reversed
+	shape := slices.WithLen[int](len(h.shape))
+	axes := slices.WithLen[int](len(h.shape))
+	for i := range shape {
+		shape[i] = h.shape[len(h.shape)-1-i]
+		axes[i] = len(h.shape) - 1 - i
+	}
+
+	t := Tensor[T]{
+		data:   data,
+		shape:  shape,
+		stride: configStride(shape),
+	}
+
+	return t.Permute(axes...).Contiguous(), nil
+}
+
+// WriteNpz writes the named Tensors to the writer as an uncompressed
+// NumPy .npz archive, where each Tensor is stored under its name.
+func WriteNpz[T Number](w io.Writer, ts map[string]Tensor[T]) error {
+	names := make([]string, 0, len(ts))
+	for name := range ts {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	z := zip.NewWriter(w)
+
+	for _, name := range names {
+		f, err := z.CreateHeader(&zip.FileHeader{
+			Name:   name + ".npy",
+			Method: zip.Store,
+		})
+		if err != nil {
+			return err
+		}
+
+		if err := WriteNpy(f, ts[name]); err != nil {
+			return err
+		}
+	}
+
+	return z.Close()
+}
+
+// ReadNpz reads all the Tensors of a NumPy .npz archive, compressed
+// or not, from the reader, casting their elements to the given
+// numeric type, and returns them by name.
+func ReadNpz[T Number](r io.Reader) (map[string]Tensor[T], error) {
+	b, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+
+	z, err := zip.NewReader(bytes.NewReader(b), int64(len(b)))
+	if err != nil {
+		return nil, err
+	}
+
+	ts := make(map[string]Tensor[T], len(z.File))
+
+	for _, f := range z.File {
+		if !strings.HasSuffix(f.Name, ".npy") {
+			continue
+		}
+
+		rc, err := f.Open()
+		if err != nil {
+			return nil, err
+		}
+
+		t, err := readNpy[T](rc)
+		rc.Close()
+		if err != nil {
+			return nil, err
+		}
+
+		ts[strings.TrimSuffix(f.Name, ".npy")] = t
+	}
+
+	return ts, nil
+}
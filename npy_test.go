@@ -0,0 +1,62 @@
+// Copyright © The Nune Author. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package nune_test
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"io"
+	"testing"
+
+	"github.com/vorduin/nune"
+)
+
+func BenchmarkWriteNpy1e6(b *testing.B) {
+	tensor := nune.Range[float64](0, 1e6, 1)
+
+	benchmarkMicro(b, func() {
+		nune.WriteNpy(io.Discard, tensor)
+	})
+}
+
+func BenchmarkReadNpy1e6(b *testing.B) {
+	var buf bytes.Buffer
+	nune.WriteNpy(&buf, nune.Range[float64](0, 1e6, 1))
+	data := buf.Bytes()
+
+	benchmarkMicro(b, func() {
+		nune.ReadNpy[float64](bytes.NewReader(data))
+	})
+}
+
+// npyWithShape returns the header of a .npy file
+// of float64 elements of the given shape.
+func npyWithShape(shape string) []byte {
+	dict := "{'descr': '<f8', 'fortran_order': False, 'shape': " + shape + ", }\n"
+
+	var b bytes.Buffer
+	b.WriteString("\x93NUMPY\x01\x00")
+	binary.Write(&b, binary.LittleEndian, uint16(len(dict)))
+	b.WriteString(dict)
+
+	return b.Bytes()
+}
+
+func TestReadNpyBadShape(t *testing.T) {
+	for _, shape := range []string{"(-1, 4)", "(4611686018427387904, 4)", "(3037000500, 3037000500)"} {
+		tensor := nune.ReadNpy[float64](bytes.NewReader(npyWithShape(shape)))
+		if !errors.Is(tensor.Err, nune.ErrBadNpy) {
+			t.Errorf("ReadNpy %s: got error %v, want %v", shape, tensor.Err, nune.ErrBadNpy)
+		}
+	}
+
+	// a header announcing more data than there is fails without
+	// allocating for the announced data
+	tensor := nune.ReadNpy[float64](bytes.NewReader(npyWithShape("(1099511627776,)")))
+	if !errors.Is(tensor.Err, io.ErrUnexpectedEOF) && !errors.Is(tensor.Err, io.EOF) {
+		t.Errorf("ReadNpy: got error %v, want %v", tensor.Err, io.EOF)
+	}
+}
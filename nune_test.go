@@ -37,13 +37,17 @@ func benchmarkMilli(b *testing.B, f func()) {
 	b.ReportMetric((1e3*execTime.Seconds())/float64(b.N), "ms/op")
 }
 
+// engine is the Engine the benchmarked Tensors are bound to,
+// so that benchmarks don't modify Nune's global configuration.
+var engine = nune.NewEngine()
+
 func newTensor() nune.Tensor[float64] {
-	return nune.Range[float64](0, 1e7, 1)
+	return nune.Range[float64](0, 1e7, 1).WithEngine(engine)
 }
 
 func benchmarkOp(b *testing.B, f func()) {
 	b.Run("1e7Float64Procs1", func(b *testing.B) {
-		nune.EnvConfig.NumCPU = 1
+		engine.NumCPU = 1
 
 		benchmarkMilli(b, func() {
 			f()
@@ -51,7 +55,7 @@ func benchmarkOp(b *testing.B, f func()) {
 	})
 
 	b.Run("1e7Float64ProcsN", func(b *testing.B) {
-		nune.EnvConfig.NumCPU = 0
+		engine.NumCPU = 0
 		
 		benchmarkMilli(b, func() {
 			f()
@@ -0,0 +1,64 @@
+// Copyright © The Nune Author. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package nune
+
+import (
+	"runtime"
+
+	"github.com/vorduin/nune/internal/pool"
+)
+
+func init() {
+	pool.DefaultSize = func() int {
+		return configWorkers(0)
+	}
+}
+
+// configWorkers returns the number of workers of a pool of the given size,
+// where a size of 0 defers to EnvConfig.NumCPU, or to the number of CPUs.
+func configWorkers(n int) int {
+	if n <= 0 {
+		n = EnvConfig.NumCPU
+	}
+	if n <= 0 {
+		n = runtime.NumCPU()
+	}
+
+	return n
+}
+
+// StartPool starts the worker pool shared by all parallel operations
+// with n workers, or resizes it if it's already running. A size of 0
+// defers to EnvConfig.NumCPU, or to the number of CPUs if it's also 0.
+// The pool is otherwise started the first time it's needed.
+func StartPool(n int) {
+	pool.Start(configWorkers(n))
+}
+
+// ResizePool sets the number of workers of the running worker pool,
+// using the same rules as StartPool. It does nothing if the pool
+// isn't running.
+func ResizePool(n int) {
+	pool.Resize(configWorkers(n))
+}
+
+// ShutdownPool stops the workers of the worker pool and waits for
+// them to exit. Parallel operations then run on their caller's
+// goroutine until the pool is started again with StartPool.
+func ShutdownPool() {
+	pool.Shutdown()
+}
+
+// PoolSize returns the number of running workers in the worker pool.
+func PoolSize() int {
+	return pool.Size()
+}
+
+// parallelize splits the interval [0, size) into nCPU shares
+// and concurrently calls f on each share, along with its number,
+// on the worker pool.
+func parallelize(size, nCPU int, f func(i, min, max int)) {
+	pool.Parallelize(size, nCPU, f)
+}
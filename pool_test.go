@@ -0,0 +1,29 @@
+// Copyright © The Nune Author. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package nune_test
+
+import (
+	"testing"
+
+	"github.com/vorduin/nune"
+)
+
+func BenchmarkPoolSum1e5(b *testing.B) {
+	e := nune.NewEngine()
+	e.NumCPU = 8
+
+	tensor := nune.Range[float64](0, 1e5, 1).WithEngine(e)
+
+	benchmarkMicro(b, func() {
+		tensor.Sum()
+	})
+}
+
+func BenchmarkPoolResize(b *testing.B) {
+	benchmarkMicro(b, func() {
+		nune.ResizePool(2)
+		nune.ResizePool(0)
+	})
+}
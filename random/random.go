@@ -0,0 +1,173 @@
+// Copyright © The Nune Author. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package random implements seeded, reproducible generation
+// of Nune Tensors filled with pseudo-random numbers.
+//
+// Every sampling function draws from an explicit Generator.
+// The samples are generated in fixed-size blocks, each with its own
+// stream derived from the Generator's seed, so the results only depend
+// on the seed and the sequence of calls, and never on the number of
+// CPUs used to generate them concurrently.
+package random
+
+import (
+	"errors"
+	"math/bits"
+	"math/rand"
+	"runtime"
+	"sync/atomic"
+
+	"github.com/vorduin/nune"
+	"github.com/vorduin/nune/internal/pool"
+)
+
+// ErrSampleSize occurs when a larger sample than the population
+// is requested while sampling without replacement.
+var ErrSampleSize = errors.New("random: sample is larger than the population")
+
+// blockSize is the number of samples generated by
+// each stream when generating samples concurrently.
+const blockSize = 4096
+
+// A Generator is a seeded source of pseudo-random Tensors.
+// It is safe for concurrent use, though the samples are only
+// reproducible if the calls happen in a deterministic order.
+type Generator struct {
+	seed    uint64       // the seed all streams are derived from
+	streams uint64       // the number of streams already used
+	eng     *nune.Engine // the Engine the samples are bound to, if any
+}
+
+// NewGenerator returns a new Generator seeded with the given value.
+func NewGenerator(seed uint64) *Generator {
+	return &Generator{
+		seed: seed,
+	}
+}
+
+// Seed resets the Generator to the state of a new Generator
+// seeded with the given value.
+func (g *Generator) Seed(seed uint64) {
+	atomic.StoreUint64(&g.seed, seed)
+	atomic.StoreUint64(&g.streams, 0)
+}
+
+// SetEngine binds the Tensors sampled from the Generator to the
+// given Engine, whose configuration is then used to generate them.
+// A nil Engine binds them back to Nune's global defaults.
+// It must not be called concurrently with sampling.
+func (g *Generator) SetEngine(e *nune.Engine) {
+	g.eng = e
+}
+
+// engine returns the configuration in effect for the Generator.
+func (g *Generator) engine() *nune.Engine {
+	if g.eng != nil {
+		return g.eng
+	}
+
+	return nune.NewEngine()
+}
+
+// stream reserves the next stream of the Generator,
+// and returns the seed it's derived from.
+func (g *Generator) stream() uint64 {
+	s := atomic.AddUint64(&g.streams, 1)
+	return splitmix(splitmix(atomic.LoadUint64(&g.seed)) ^ s)
+}
+
+// splitmix returns the SplitMix64 hash of x.
+func splitmix(x uint64) uint64 {
+	x += 0x9e3779b97f4a7c15
+	x = (x ^ (x >> 30)) * 0xbf58476d1ce4e5b9
+	x = (x ^ (x >> 27)) * 0x94d049bb133111eb
+	return x ^ (x >> 31)
+}
+
+// A source is a xoshiro256** pseudo-random number generator.
+// It implements rand.Source64.
+type source struct {
+	s [4]uint64
+}
+
+// newRand returns a new rand.Rand drawing from
+// the block of the given stream.
+func newRand(stream, block uint64) *rand.Rand {
+	src := &source{}
+	src.Seed(int64(splitmix(stream ^ splitmix(block))))
+	return rand.New(src)
+}
+
+// Seed seeds the source's state with SplitMix64 outputs.
+func (src *source) Seed(seed int64) {
+	x := uint64(seed)
+	for i := range src.s {
+		x += 0x9e3779b97f4a7c15
+		src.s[i] = splitmix(x)
+	}
+}
+
+// Uint64 returns a pseudo-random 64-bit value.
+func (src *source) Uint64() uint64 {
+	s := &src.s
+	res := bits.RotateLeft64(s[1]*5, 7) * 9
+
+	t := s[1] << 17
+	s[2] ^= s[0]
+	s[3] ^= s[1]
+	s[1] ^= s[2]
+	s[0] ^= s[3]
+	s[2] ^= t
+	s[3] = bits.RotateLeft64(s[3], 45)
+
+	return res
+}
+
+// Int63 returns a non-negative pseudo-random 63-bit integer.
+func (src *source) Int63() int64 {
+	return int64(src.Uint64() >> 1)
+}
+
+// fill fills the buffer with samples drawn by f, block by block,
+// where the blocks are generated concurrently on Nune's worker pool
+// in at most nCPU shares, or in as many as there are CPUs if nCPU is 0.
+func fill[T nune.Number](stream uint64, buf []T, nCPU int, f func(r *rand.Rand) T) {
+	numBlocks := (len(buf) + blockSize - 1) / blockSize
+
+	if nCPU == 0 {
+		nCPU = runtime.NumCPU()
+	}
+	if nCPU > numBlocks {
+		nCPU = numBlocks
+	}
+
+	pool.Parallelize(numBlocks, nCPU, func(_, min, max int) {
+		for b := min; b < max; b++ {
+			r := newRand(stream, uint64(b))
+
+			end := (b + 1) * blockSize
+			if end > len(buf) {
+				end = len(buf)
+			}
+
+			block := buf[b*blockSize : end]
+			for j := range block {
+				block[j] = f(r)
+			}
+		}
+	})
+}
+
+// fail returns a Tensor holding the given error,
+// or panics if the Generator's environment is interactive.
+func fail[T nune.Number](g *Generator, err error) nune.Tensor[T] {
+	if g.engine().Interactive {
+		panic(err)
+	}
+
+	return nune.Tensor[T]{
+		Err: err,
+	}.WithEngine(g.eng)
+}
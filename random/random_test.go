@@ -0,0 +1,99 @@
+// Copyright © The Nune Author. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package random_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/vorduin/nune"
+	"github.com/vorduin/nune/random"
+)
+
+func benchmarkMilli(b *testing.B, f func()) {
+	b.ResetTimer()
+
+	start := time.Now()
+	for i := 0; i < b.N; i++ {
+		f()
+	}
+	execTime := time.Since(start)
+
+	b.ReportMetric(0, "ns/op")
+	b.ReportMetric((1e3*execTime.Seconds())/float64(b.N), "ms/op")
+}
+
+func benchmarkOp(b *testing.B, f func()) {
+	b.Run("1e7Float64Procs1", func(b *testing.B) {
+		nune.EnvConfig.NumCPU = 1
+
+		benchmarkMilli(b, func() {
+			f()
+		})
+	})
+
+	b.Run("1e7Float64ProcsN", func(b *testing.B) {
+		nune.EnvConfig.NumCPU = 0
+
+		benchmarkMilli(b, func() {
+			f()
+		})
+	})
+}
+
+func BenchmarkRand(b *testing.B) {
+	g := random.NewGenerator(0)
+
+	benchmarkOp(b, func() {
+		random.Rand[float64](g, 1e7)
+	})
+}
+
+func BenchmarkRandN(b *testing.B) {
+	g := random.NewGenerator(0)
+
+	benchmarkOp(b, func() {
+		random.RandN[float64](g, 1e7)
+	})
+}
+
+func BenchmarkRandInt(b *testing.B) {
+	g := random.NewGenerator(0)
+
+	benchmarkOp(b, func() {
+		random.RandInt[float64](g, 0, 100, 1e7)
+	})
+}
+
+func BenchmarkPermutation(b *testing.B) {
+	g := random.NewGenerator(0)
+
+	benchmarkOp(b, func() {
+		random.Permutation[float64](g, 1e7)
+	})
+}
+
+func TestRandPool(t *testing.T) {
+	e := nune.NewEngine()
+	e.NumCPU = 8
+
+	g := random.NewGenerator(0)
+	g.SetEngine(e)
+	want := random.Rand[float64](g, 1e5).Ravel()
+
+	// the samples are generated on the caller's
+	// goroutine once the pool is shut down
+	nune.ShutdownPool()
+	defer nune.StartPool(0)
+
+	g.Seed(0)
+	got := random.Rand[float64](g, 1e5).Ravel()
+
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("Rand: sample %d differs without the pool: got %v, want %v", i, got[i], want[i])
+		}
+	}
+}
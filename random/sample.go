@@ -0,0 +1,165 @@
+// Copyright © The Nune Author. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package random
+
+import (
+	"errors"
+	"math/rand"
+
+	"github.com/vorduin/nune"
+)
+
+// ErrBadProbability occurs when a probability
+// falls outside of the [0, 1] interval.
+var ErrBadProbability = errors.New("random: probability out of [0, 1] bounds")
+
+// sample returns a Tensor of the given shape
+// filled with samples drawn from the Generator by f.
+func sample[T nune.Number](g *Generator, shape []int, f func(r *rand.Rand) T) nune.Tensor[T] {
+	t := nune.Zeros[T](shape...).WithEngine(g.eng)
+	if t.Err != nil {
+		return t
+	}
+
+	fill(g.stream(), t.Ravel(), g.engine().NumCPU, f)
+
+	return t
+}
+
+// Rand returns a Tensor of the given shape filled with
+// samples drawn uniformly from the interval [0, 1).
+func Rand[T nune.Number](g *Generator, shape ...int) nune.Tensor[T] {
+	return sample(g, shape, func(r *rand.Rand) T {
+		return T(r.Float64())
+	})
+}
+
+// Uniform returns a Tensor of the given shape filled with
+// samples drawn uniformly from the interval [low, high).
+func Uniform[T nune.Number](g *Generator, low, high float64, shape ...int) nune.Tensor[T] {
+	if low >= high {
+		return fail[T](g, nune.ErrBadInterval)
+	}
+
+	return sample(g, shape, func(r *rand.Rand) T {
+		return T(low + (high-low)*r.Float64())
+	})
+}
+
+// RandN returns a Tensor of the given shape filled with
+// samples drawn from the standard normal distribution.
+func RandN[T nune.Number](g *Generator, shape ...int) nune.Tensor[T] {
+	return sample(g, shape, func(r *rand.Rand) T {
+		return T(r.NormFloat64())
+	})
+}
+
+// Normal returns a Tensor of the given shape filled with samples drawn
+// from the normal distribution with the given mean and standard deviation.
+func Normal[T nune.Number](g *Generator, mean, std float64, shape ...int) nune.Tensor[T] {
+	return sample(g, shape, func(r *rand.Rand) T {
+		return T(mean + std*r.NormFloat64())
+	})
+}
+
+// RandInt returns a Tensor of the given shape filled with
+// integers drawn uniformly from the interval [low, high).
+func RandInt[T nune.Number](g *Generator, low, high int, shape ...int) nune.Tensor[T] {
+	if low >= high {
+		return fail[T](g, nune.ErrBadInterval)
+	}
+
+	return sample(g, shape, func(r *rand.Rand) T {
+		return T(low + int(r.Int63n(int64(high-low))))
+	})
+}
+
+// Bernoulli returns a Tensor of the given shape filled with
+// ones with the probability p, and with zeros otherwise.
+func Bernoulli[T nune.Number](g *Generator, p float64, shape ...int) nune.Tensor[T] {
+	if p < 0 || p > 1 {
+		return fail[T](g, ErrBadProbability)
+	}
+
+	return sample(g, shape, func(r *rand.Rand) T {
+		if r.Float64() < p {
+			return 1
+		}
+		return 0
+	})
+}
+
+// Permutation returns a rank 1 Tensor holding
+// a random permutation of the integers [0, n).
+func Permutation[T nune.Number](g *Generator, n int) nune.Tensor[T] {
+	t := nune.Zeros[T](n).WithEngine(g.eng)
+	if t.Err != nil {
+		return t
+	}
+
+	buf := t.Ravel()
+	for i, x := range newRand(g.stream(), 0).Perm(n) {
+		buf[i] = T(x)
+	}
+
+	return t
+}
+
+// Shuffle returns a copy of the Tensor whose
+// elements are shuffled along its first axis.
+func Shuffle[T nune.Number](g *Generator, t nune.Tensor[T]) nune.Tensor[T] {
+	if t.Err != nil {
+		return fail[T](g, t.Err)
+	}
+
+	c := t.Clone()
+	if c.Rank() == 0 {
+		return c
+	}
+
+	n := c.Size(0)
+	rowLen := c.Numel() / n
+	src := c.Ravel()
+	dst := make([]T, len(src))
+
+	for i, j := range newRand(g.stream(), 0).Perm(n) {
+		copy(dst[i*rowLen:(i+1)*rowLen], src[j*rowLen:(j+1)*rowLen])
+	}
+
+	return nune.FromBuffer(dst).WithEngine(g.eng).Reshape(c.Shape()...)
+}
+
+// Choice returns a rank 1 Tensor holding k elements sampled uniformly
+// from the Tensor's elements, with or without replacement.
+func Choice[T nune.Number](g *Generator, t nune.Tensor[T], k int, replace bool) nune.Tensor[T] {
+	if t.Err != nil {
+		return fail[T](g, t.Err)
+	}
+
+	population := t.Clone().Ravel()
+
+	if k <= 0 {
+		return fail[T](g, nune.ErrBadShape)
+	}
+
+	if !replace && k > len(population) {
+		return fail[T](g, ErrSampleSize)
+	}
+
+	if replace {
+		return sample(g, []int{k}, func(r *rand.Rand) T {
+			return population[r.Intn(len(population))]
+		})
+	}
+
+	// partial Fisher-Yates shuffle of the population's copy
+	r := newRand(g.stream(), 0)
+	for i := 0; i < k; i++ {
+		j := i + r.Intn(len(population)-i)
+		population[i], population[j] = population[j], population[i]
+	}
+
+	return nune.FromBuffer(population[:k]).WithEngine(g.eng)
+}
@@ -4,34 +4,102 @@
 
 package nune
 
-// handleReduce processes a slice reduction operation accordingly.
-func handleReduce[T Number](in []T, out *T, f func([]T) T, nCPU int) {
-	outBuf := make([]T, 0, nCPU)
-	ch := make(chan T, nCPU)
+import (
+	"github.com/vorduin/slices"
+)
 
-	for i := 0; i < nCPU; i++ {
-		min := (i * len(in) / nCPU)
-		max := ((i + 1) * len(in)) / nCPU
+// handleShares calls f on the elements of each share of a view, along
+// with the share's number, where the view is split into the given number
+// of shares which are processed concurrently. The elements are read
+// directly from the data buffer when the view is contiguous, and are
+// otherwise gathered into a buffer that's only valid during the call to f.
+func handleShares[T Number](t Tensor[T], f func(i int, s []T), shares int) {
+	shape, strides := coalesce(t.shape, t.stride)
+	size := t.Numel()
 
-		go func(inBuf []T, c chan<- T) {
-			c <- f(inBuf)
-		}(in[min:max], ch)
+	nCPU := t.env().configCPU(size)
+	if nCPU > shares {
+		nCPU = shares
 	}
 
-	for i := 0; i < nCPU; i++ {
-		outBuf = append(outBuf, <-ch)
-	}
+	parallelize(shares, nCPU, func(_, first, last int) {
+		var buf []T
+
+		for i := first; i < last; i++ {
+			min, max := i*size/shares, (i+1)*size/shares
+
+			var inBuf []T
+			if len(shape) <= 1 && (len(shape) == 0 || strides[0][0] == 1) {
+				inBuf = t.data[t.offset+min : t.offset+max]
+			} else {
+				if cap(buf) < max-min {
+					buf = slices.WithLen[T](max - min)
+				}
+				inBuf = buf[:max-min]
+
+				w := newWalker(shape, strides[0], t.offset, min)
+				for j := 0; j < len(inBuf); {
+					pos, step, l := w.run(len(inBuf) - j)
+					for k := 0; k < l; k++ {
+						inBuf[j] = t.data[pos]
+						pos += step
+						j++
+					}
+				}
+			}
+
+			f(i, inBuf)
+		}
+	})
+}
+
+// handleReduce processes a slice reduction operation accordingly,
+// walking over the Tensor's view in its data buffer.
+func handleReduce[T Number](t Tensor[T], out *T, f func([]T) T, shares int) {
+	outBuf := slices.WithLen[T](shares)
+
+	handleShares(t, func(i int, s []T) {
+		outBuf[i] = f(s)
+	}, shares)
 
 	*out = f(outBuf)
 }
 
+// handleReduceAxis processes a slice reduction operation along
+// the given axis of a strided view accordingly, where each
+// share of the non-reduced positions is reduced concurrently.
+func handleReduceAxis[T Number](t Tensor[T], axis int, out []T, f func([]T) T, nCPU int) {
+	parallelize(len(out), nCPU, func(_, min, max int) {
+		lane := slices.WithLen[T](t.shape[axis])
+
+		for j := min; j < max; j++ {
+			// unravel j over the non-reduced axes
+			pos, r := t.offset, j
+			for k := len(t.shape) - 1; k >= 0; k-- {
+				if k != axis {
+					pos += (r % t.shape[k]) * t.stride[k]
+					r /= t.shape[k]
+				}
+			}
+
+			for k := 0; k < len(lane); k++ {
+				lane[k] = t.data[pos+k*t.stride[axis]]
+			}
+
+			out[j] = f(lane)
+		}
+	})
+}
+
 // Reduce performs a reduction operation over all elements in the Tensor.
 // The reduction operation must be able to generalize and parallelize
 // since the operation might be multi-threaded if the Tensor is big enough,
 // unless explicitely disabled in Nune's environment configuration.
+// In a deterministic environment, the elements are split into shares
+// that only depend on their number, rather than on the number of CPUs.
 func (t Tensor[T]) Reduce(f func([]T) T) Tensor[T] {
 	if t.Err != nil {
-		if EnvConfig.Interactive {
+		if t.env().Interactive {
 			panic(t.Err)
 		} else {
 			return t
@@ -39,68 +107,311 @@ func (t Tensor[T]) Reduce(f func([]T) T) Tensor[T] {
 	}
 
 	var res T
-	handleReduce(t.Ravel(), &res, f, configCPU(t.Numel()))
+	handleReduce(t, &res, f, t.env().configShares(t.Numel()))
 
 	return Tensor[T]{
 		data: []T{res},
+		eng:  t.eng,
+	}
+}
+
+// ReduceAxis performs a reduction operation over all elements along
+// the given axis of the Tensor, removing that axis from the Tensor's
+// shape, or keeping it with dimensions 1 if keepDims is true.
+// The reduction operation receives the elements of one lane at a time,
+// and the lanes might be reduced in parallel if the Tensor is big enough.
+func (t Tensor[T]) ReduceAxis(axis int, keepDims bool, f func([]T) T) Tensor[T] {
+	if t.Err != nil {
+		if t.env().Interactive {
+			panic(t.Err)
+		} else {
+			return t
+		}
+	}
+
+	err := verifyAxisBounds(axis, t.Rank()-1)
+	if err != nil {
+		err = newOpError("ReduceAxis", err, axis, t.shape)
+		if t.env().Interactive {
+			panic(err)
+		} else {
+			t.Err = err
+			return t
+		}
+	}
+
+	var shape []int
+	if keepDims {
+		shape = slices.Clone(t.shape)
+		shape[axis] = 1
+	} else {
+		shape = slices.WithLen[int](len(t.shape) - 1)
+		copy(shape[:axis], t.shape[:axis])
+		copy(shape[axis:], t.shape[axis+1:])
+	}
+
+	out := slices.WithLen[T](t.Numel() / t.shape[axis])
+	handleReduceAxis(t, axis, out, f, t.env().configCPU(t.Numel()))
+
+	return Tensor[T]{
+		data:   out,
+		shape:  shape,
+		stride: configStride(shape),
+		eng:    t.eng,
+	}
+}
+
+// reduceMin returns the minimum value of a slice.
+func reduceMin[T Number](s []T) T {
+	if x, ok := reduceKernel(opMin, s); ok {
+		return x
+	}
+
+	min := s[0]
+	for i := 1; i < len(s); i++ {
+		if s[i] < min {
+			min = s[i]
+		}
+	}
+	return min
+}
+
+// reduceMax returns the maximum value of a slice.
+func reduceMax[T Number](s []T) T {
+	if x, ok := reduceKernel(opMax, s); ok {
+		return x
+	}
+
+	max := s[0]
+	for i := 1; i < len(s); i++ {
+		if s[i] > max {
+			max = s[i]
+		}
+	}
+	return max
+}
+
+// reduceSum64 returns the sum of a slice as a float64,
+// accumulated in float64 unless it has a dedicated kernel.
+func reduceSum64[T Number](s []T) float64 {
+	if x, ok := reduceKernel(opSum, s); ok {
+		return float64(x)
+	}
+
+	var sum float64
+	for i := 0; i < len(s); i++ {
+		sum += float64(s[i])
+	}
+	return sum
+}
+
+// reduceSum returns the sum of a slice.
+func reduceSum[T Number](s []T) T {
+	if x, ok := reduceKernel(opSum, s); ok {
+		return x
+	}
+
+	var sum T
+	for i := 0; i < len(s); i++ {
+		sum += s[i]
+	}
+	return sum
+}
+
+// pairwiseBlock is the number of elements up to
+// which pairwise summation sums the elements naively.
+const pairwiseBlock = 256
+
+// sumPairwise returns the sum of a slice by pairwise summation.
+func sumPairwise[T Number](s []T) T {
+	if len(s) <= pairwiseBlock {
+		return reduceSum(s)
 	}
+
+	// split on a multiple of the block size
+	h := (len(s)/2 + pairwiseBlock - 1) / pairwiseBlock * pairwiseBlock
+	return sumPairwise(s[:h]) + sumPairwise(s[h:])
+}
+
+// sumPairwise64 returns the sum of a slice
+// as a float64 by pairwise summation.
+func sumPairwise64[T Number](s []T) float64 {
+	if len(s) <= pairwiseBlock {
+		return reduceSum64(s)
+	}
+
+	// split on a multiple of the block size
+	h := (len(s)/2 + pairwiseBlock - 1) / pairwiseBlock * pairwiseBlock
+	return sumPairwise64(s[:h]) + sumPairwise64(s[h:])
+}
+
+// sumKahan returns the sum of a slice by Kahan–Babuška summation,
+// accumulated in float64, since the compensation term of narrower
+// floats can't hold the bits lost by long sums.
+func sumKahan[T Number](s []T) T {
+	return T(sumKahan64(s))
+}
+
+// sumKahan64 returns the sum of a slice as a float64 by Kahan–Babuška
+// summation, where the low-order bits lost by each addition are
+// accumulated into a separate compensation term, added at last.
+func sumKahan64[T Number](s []T) float64 {
+	var sum, c float64
+	for _, x := range s {
+		x := float64(x)
+		t := sum + x
+		if abs(sum) >= abs(x) {
+			c += (sum - t) + x
+		} else {
+			c += (x - t) + sum
+		}
+		sum = t
+	}
+
+	// the compensation term is a NaN once the sum is infinite
+	if isNaN(c) {
+		return sum
+	}
+	return sum + c
+}
+
+// abs returns the absolute value of x.
+func abs[T Number](x T) T {
+	if x < 0 {
+		return -x
+	}
+	return x
+}
+
+// isFloat returns whether T is a floating-point type.
+func isFloat[T Number]() bool {
+	var half T = 1
+	half /= 2
+	return half != 0
+}
+
+// summation returns the function summing a slice with the given
+// algorithm. Slices of integers are always summed naively.
+func summation[T Number](alg Summation) func([]T) T {
+	if !isFloat[T]() {
+		return reduceSum[T]
+	}
+
+	switch alg {
+	case SumKahan:
+		return sumKahan[T]
+	case SumNaive:
+		return reduceSum[T]
+	default:
+		return sumPairwise[T]
+	}
+}
+
+// summation64 returns the function summing a slice
+// as a float64 with the given algorithm.
+func summation64[T Number](alg Summation) func([]T) float64 {
+	if !isFloat[T]() {
+		return reduceSum64[T]
+	}
+
+	switch alg {
+	case SumKahan:
+		return sumKahan64[T]
+	case SumNaive:
+		return reduceSum64[T]
+	default:
+		return sumPairwise64[T]
+	}
+}
+
+// reduceProd returns the product of a slice.
+func reduceProd[T Number](s []T) T {
+	var prod T = 1
+	for i := 0; i < len(s); i++ {
+		prod *= s[i]
+	}
+	return prod
 }
 
 // Min returns the minimum value of all elements in the Tensor.
 func (t Tensor[T]) Min() Tensor[T] {
-	return t.Reduce(func(s []T) T {
-		min := s[0]
-		for i := 1; i < len(s); i++ {
-			if s[i] < min {
-				min = s[i]
-			}
-		}
-		return min
-	})
+	return t.Reduce(reduceMin[T])
 }
 
 // Max returns the maximum value of all elements in the Tensor.
 func (t Tensor[T]) Max() Tensor[T] {
-	return t.Reduce(func(s []T) T {
-		max := s[0]
-		for i := 1; i < len(s); i++ {
-			if s[i] > max {
-				max = s[i]
-			}
-		}
-		return max
-	})
+	return t.Reduce(reduceMax[T])
 }
 
 // Mean returns the mean value of all elements in the Tensor.
+// The sums of the shares of the elements are accumulated in float64,
+// with the environment's summation algorithm, and combined before
+// being divided by the number of elements.
 func (t Tensor[T]) Mean() Tensor[T] {
-	return t.Reduce(func(s []T) T {
-		var sum T
-		for i := 0; i < len(s); i++ {
-			sum += s[i]
+	if t.Err != nil {
+		if t.env().Interactive {
+			panic(t.Err)
+		} else {
+			return t
 		}
-		return sum / T(len(s))
-	})
+	}
+
+	shares := t.env().configShares(t.Numel())
+	sums := slices.WithLen[float64](shares)
+	sum := summation64[T](t.env().Summation)
+
+	handleShares(t, func(i int, s []T) {
+		sums[i] = sum(s)
+	}, shares)
+
+	return Tensor[T]{
+		data: []T{T(summation64[float64](t.env().Summation)(sums) / float64(t.Numel()))},
+		eng:  t.eng,
+	}
 }
 
-// Sum returns the sum of all elements in the Tensor.
+// Sum returns the sum of all elements in the Tensor,
+// with the environment's summation algorithm.
 func (t Tensor[T]) Sum() Tensor[T] {
-	return t.Reduce(func(s []T) T {
-		var sum T
-		for i := 0; i < len(s); i++ {
-			sum += s[i]
-		}
-		return sum
-	})
+	return t.Reduce(summation[T](t.env().Summation))
 }
 
 // Prod returns the product of all elements in the Tensor.
 func (t Tensor[T]) Prod() Tensor[T] {
-	return t.Reduce(func(s []T) T {
-		var prod T = 1
-		for i := 0; i < len(s); i++ {
-			prod *= s[i]
-		}
-		return prod
+	return t.Reduce(reduceProd[T])
+}
+
+// MinAxis returns the minimum value of the elements
+// along the given axis of the Tensor.
+func (t Tensor[T]) MinAxis(axis int, keepDims bool) Tensor[T] {
+	return t.ReduceAxis(axis, keepDims, reduceMin[T])
+}
+
+// MaxAxis returns the maximum value of the elements
+// along the given axis of the Tensor.
+func (t Tensor[T]) MaxAxis(axis int, keepDims bool) Tensor[T] {
+	return t.ReduceAxis(axis, keepDims, reduceMax[T])
+}
+
+// MeanAxis returns the mean value of the elements along the given
+// axis of the Tensor, whose sums are accumulated in float64 with
+// the environment's summation algorithm.
+func (t Tensor[T]) MeanAxis(axis int, keepDims bool) Tensor[T] {
+	sum := summation64[T](t.env().Summation)
+
+	return t.ReduceAxis(axis, keepDims, func(s []T) T {
+		return T(sum(s) / float64(len(s)))
 	})
 }
+
+// SumAxis returns the sum of the elements along the given axis
+// of the Tensor, with the environment's summation algorithm.
+func (t Tensor[T]) SumAxis(axis int, keepDims bool) Tensor[T] {
+	return t.ReduceAxis(axis, keepDims, summation[T](t.env().Summation))
+}
+
+// ProdAxis returns the product of the elements
+// along the given axis of the Tensor.
+func (t Tensor[T]) ProdAxis(axis int, keepDims bool) Tensor[T] {
+	return t.ReduceAxis(axis, keepDims, reduceProd[T])
+}
@@ -6,6 +6,8 @@ package nune_test
 
 import (
 	"testing"
+
+	"github.com/vorduin/nune"
 )
 
 func BenchmarkMin(b *testing.B) {
@@ -40,10 +42,96 @@ func BenchmarkSum(b *testing.B) {
 	})
 }
 
+func BenchmarkSumKahan(b *testing.B) {
+	e := nune.NewEngine()
+	e.Summation = nune.SumKahan
+	tensor := newTensor().WithEngine(e)
+
+	benchmarkOp(b, func() {
+		tensor.Sum()
+	})
+}
+
+func BenchmarkSumNaive(b *testing.B) {
+	e := nune.NewEngine()
+	e.Summation = nune.SumNaive
+	tensor := newTensor().WithEngine(e)
+
+	benchmarkOp(b, func() {
+		tensor.Sum()
+	})
+}
+
+func BenchmarkSumDeterministic(b *testing.B) {
+	e := nune.NewEngine()
+	e.Deterministic = true
+	tensor := newTensor().WithEngine(e)
+
+	benchmarkOp(b, func() {
+		tensor.Sum()
+	})
+}
+
 func BenchmarkProd(b *testing.B) {
 	tensor := newTensor()
 
 	benchmarkOp(b, func() {
 		tensor.Prod()
 	})
-}
\ No newline at end of file
+}
+
+func BenchmarkMinAxis(b *testing.B) {
+	tensor := newTensor().Reshape(1e4, 1e3)
+
+	benchmarkOp(b, func() {
+		tensor.MinAxis(0, false)
+	})
+}
+
+func BenchmarkMaxAxis(b *testing.B) {
+	tensor := newTensor().Reshape(1e4, 1e3)
+
+	benchmarkOp(b, func() {
+		tensor.MaxAxis(0, false)
+	})
+}
+
+func BenchmarkMeanAxis(b *testing.B) {
+	tensor := newTensor().Reshape(1e4, 1e3)
+
+	benchmarkOp(b, func() {
+		tensor.MeanAxis(0, false)
+	})
+}
+
+func BenchmarkSumAxis(b *testing.B) {
+	tensor := newTensor().Reshape(1e4, 1e3)
+
+	benchmarkOp(b, func() {
+		tensor.SumAxis(0, false)
+	})
+}
+
+func BenchmarkProdAxis(b *testing.B) {
+	tensor := newTensor().Reshape(1e4, 1e3)
+
+	benchmarkOp(b, func() {
+		tensor.ProdAxis(0, false)
+	})
+}
+
+func BenchmarkCumSum(b *testing.B) {
+	tensor := newTensor()
+
+	benchmarkOp(b, func() {
+		tensor.CumSum(0)
+	})
+}
+
+func BenchmarkCumSumAxis(b *testing.B) {
+	tensor := newTensor().Reshape(1e4, 1e3)
+
+	benchmarkOp(b, func() {
+		tensor.CumSum(0)
+	})
+}
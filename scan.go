@@ -0,0 +1,167 @@
+// Copyright © The Nune Author. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package nune
+
+import (
+	"github.com/vorduin/slices"
+)
+
+// handleScan processes a prefix scan operation in place along the middle
+// axis of a contiguous buffer laid out as (outer, n, inner), where each
+// lane along the middle axis is scanned over independently.
+// When there are enough lanes, the shares of lanes are scanned concurrently,
+// otherwise each lane is scanned in two passes: its chunks are first scanned
+// concurrently, then each chunk is combined with the carry of the chunks
+// preceding it, which requires the operation to be associative.
+func handleScan[T Number](buf []T, outer, n, inner int, f func(acc, x T) T, nCPU int) {
+	lanes := outer * inner
+
+	if lanes >= nCPU || n < 2*nCPU {
+		parallelize(lanes, nCPU, func(_, min, max int) {
+			for o := min / inner; o*inner < max; o++ {
+				kmin, kmax := o*inner, (o+1)*inner
+				if kmin < min {
+					kmin = min
+				}
+				if kmax > max {
+					kmax = max
+				}
+
+				base := o*n*inner - o*inner
+				for i := 1; i < n; i++ {
+					prev, cur := base+(i-1)*inner, base+i*inner
+					for k := kmin; k < kmax; k++ {
+						buf[cur+k] = f(buf[prev+k], buf[cur+k])
+					}
+				}
+			}
+		})
+
+		return
+	}
+
+	// the bounds of the chunks, as split by parallelize
+	bound := func(c int) int {
+		return c * n / nCPU
+	}
+
+	// first pass: scan each chunk of each lane locally
+	parallelize(n, nCPU, func(_, min, max int) {
+		for l := 0; l < lanes; l++ {
+			o, k := l/inner, l%inner
+			base := o*n*inner + k
+
+			for i := min + 1; i < max; i++ {
+				buf[base+i*inner] = f(buf[base+(i-1)*inner], buf[base+i*inner])
+			}
+		}
+	})
+
+	// the carries of the chunks preceding each chunk of each lane
+	carries := slices.WithLen[T](lanes * nCPU)
+	for l := 0; l < lanes; l++ {
+		o, k := l/inner, l%inner
+		base := o*n*inner + k
+
+		carries[l*nCPU+1] = buf[base+(bound(1)-1)*inner]
+		for c := 2; c < nCPU; c++ {
+			carries[l*nCPU+c] = f(carries[l*nCPU+c-1], buf[base+(bound(c)-1)*inner])
+		}
+	}
+
+	// second pass: combine each chunk but the first with its carry
+	parallelize(n, nCPU, func(c, min, max int) {
+		if c == 0 {
+			return
+		}
+
+		for l := 0; l < lanes; l++ {
+			o, k := l/inner, l%inner
+			base := o*n*inner + k
+			carry := carries[l*nCPU+c]
+
+			for i := min; i < max; i++ {
+				buf[base+i*inner] = f(carry, buf[base+i*inner])
+			}
+		}
+	})
+}
+
+// Scan performs a prefix scan operation along the given axis of the
+// Tensor, where each element of the result is the accumulation of the
+// elements preceding it along the axis, and of the element itself,
+// through f, starting from the first element of the axis.
+// The scan operation must be associative since long axes might be
+// scanned in parallel chunks, as with the reduction operations.
+func (t Tensor[T]) Scan(axis int, f func(acc, x T) T) Tensor[T] {
+	if t.Err != nil {
+		if t.env().Interactive {
+			panic(t.Err)
+		} else {
+			return t
+		}
+	}
+
+	err := verifyAxisBounds(axis, t.Rank()-1)
+	if err != nil {
+		err = newOpError("Scan", err, axis, t.shape)
+		if t.env().Interactive {
+			panic(err)
+		} else {
+			t.Err = err
+			return t
+		}
+	}
+
+	out := t.Clone()
+	if out.Numel() == 0 {
+		return out
+	}
+
+	outer := slices.Prod(t.shape[:axis])
+	inner := slices.Prod(t.shape[axis+1:])
+
+	handleScan(out.data, outer, t.shape[axis], inner, f, t.env().configCPU(t.Numel()))
+
+	return out
+}
+
+// CumSum returns the cumulative sum of the elements
+// along the given axis of the Tensor.
+func (t Tensor[T]) CumSum(axis int) Tensor[T] {
+	return t.Scan(axis, func(acc, x T) T {
+		return acc + x
+	})
+}
+
+// CumProd returns the cumulative product of the elements
+// along the given axis of the Tensor.
+func (t Tensor[T]) CumProd(axis int) Tensor[T] {
+	return t.Scan(axis, func(acc, x T) T {
+		return acc * x
+	})
+}
+
+// CumMin returns the cumulative minimum of the elements
+// along the given axis of the Tensor.
+func (t Tensor[T]) CumMin(axis int) Tensor[T] {
+	return t.Scan(axis, func(acc, x T) T {
+		if x < acc {
+			return x
+		}
+		return acc
+	})
+}
+
+// CumMax returns the cumulative maximum of the elements
+// along the given axis of the Tensor.
+func (t Tensor[T]) CumMax(axis int) Tensor[T] {
+	return t.Scan(axis, func(acc, x T) T {
+		if x > acc {
+			return x
+		}
+		return acc
+	})
+}
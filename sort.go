@@ -0,0 +1,483 @@
+// Copyright © The Nune Author. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package nune
+
+import (
+	"github.com/vorduin/slices"
+)
+
+// isNaN returns whether or not the value is a NaN,
+// which is never the case for integer types.
+func isNaN[T Number](x T) bool {
+	return x != x
+}
+
+// minFirst returns whether or not x should replace y as the minimum,
+// where NaNs are propagated.
+func minFirst[T Number](x, y T) bool {
+	return x < y || (isNaN(x) && !isNaN(y))
+}
+
+// maxFirst returns whether or not x should replace y as the maximum,
+// where NaNs are propagated.
+func maxFirst[T Number](x, y T) bool {
+	return x > y || (isNaN(x) && !isNaN(y))
+}
+
+// handleArg finds the index of the first element of a view
+// that no other element should replace according to better,
+// where each share of the elements is searched concurrently.
+func handleArg[T Number](t Tensor[T], better func(x, y T) bool, nCPU int) int {
+	shape, strides := coalesce(t.shape, t.stride)
+	idxBuf := slices.WithLen[int](nCPU)
+	valBuf := slices.WithLen[T](nCPU)
+
+	parallelize(t.Numel(), nCPU, func(i, min, max int) {
+		w := newWalker(shape, strides[0], t.offset, min)
+
+		best, bestPos := min, t.offset
+		for j := min; j < max; {
+			pos, step, l := w.run(max - j)
+			if j == min {
+				bestPos = pos
+			}
+
+			for k := 0; k < l; k++ {
+				if better(t.data[pos], t.data[bestPos]) {
+					best, bestPos = j, pos
+				}
+				pos += step
+				j++
+			}
+		}
+
+		idxBuf[i], valBuf[i] = best, t.data[bestPos]
+	})
+
+	// the shares' results are combined in order, so
+	// that the first of equal elements is kept
+	best := 0
+	for i := 1; i < nCPU; i++ {
+		if better(valBuf[i], valBuf[best]) {
+			best = i
+		}
+	}
+
+	return idxBuf[best]
+}
+
+// handleLanes calls f on the elements of each lane along the given axis
+// of a view, along with the lane's number, where the lanes are numbered
+// in row-major order over the other axes, and each share of the lanes
+// is processed concurrently.
+func handleLanes[T Number](t Tensor[T], axis int, f func(j int, lane []T), nCPU int) {
+	n := t.shape[axis]
+
+	parallelize(t.Numel()/n, nCPU, func(_, min, max int) {
+		lane := slices.WithLen[T](n)
+
+		for j := min; j < max; j++ {
+			// unravel j over the non-reduced axes
+			pos, r := t.offset, j
+			for k := len(t.shape) - 1; k >= 0; k-- {
+				if k != axis {
+					pos += (r % t.shape[k]) * t.stride[k]
+					r /= t.shape[k]
+				}
+			}
+
+			for k := 0; k < n; k++ {
+				lane[k] = t.data[pos+k*t.stride[axis]]
+			}
+
+			f(j, lane)
+		}
+	})
+}
+
+// verifyAxis returns the Tensor holding the corresponding error
+// if the given axis is out of the Tensor's bounds, along with
+// whether or not the axis is valid.
+func (t Tensor[T]) verifyAxis(op string, axis int) (Tensor[T], bool) {
+	err := verifyAxisBounds(axis, t.Rank()-1)
+	if err != nil {
+		err = newOpError(op, err, axis, t.shape)
+		if t.env().Interactive {
+			panic(err)
+		} else {
+			t.Err = err
+			return t, false
+		}
+	}
+
+	return t, true
+}
+
+// argAxis returns the indices along the given axis of the elements
+// of each lane that no other element should replace according to better.
+func (t Tensor[T]) argAxis(op string, axis int, keepDims bool, better func(x, y T) bool) Tensor[int] {
+	if t.Err != nil {
+		if t.env().Interactive {
+			panic(t.Err)
+		} else {
+			return Tensor[int]{
+				Err: t.Err,
+			}
+		}
+	}
+
+	if t, ok := t.verifyAxis(op, axis); !ok {
+		return Tensor[int]{
+			Err: t.Err,
+		}
+	}
+
+	var shape []int
+	if keepDims {
+		shape = slices.Clone(t.shape)
+		shape[axis] = 1
+	} else {
+		shape = slices.WithLen[int](len(t.shape) - 1)
+		copy(shape[:axis], t.shape[:axis])
+		copy(shape[axis:], t.shape[axis+1:])
+	}
+
+	out := slices.WithLen[int](t.Numel() / t.shape[axis])
+	handleLanes(t, axis, func(j int, lane []T) {
+		best := 0
+		for k := 1; k < len(lane); k++ {
+			if better(lane[k], lane[best]) {
+				best = k
+			}
+		}
+		out[j] = best
+	}, t.env().configCPU(t.Numel()))
+
+	return Tensor[int]{
+		data:   out,
+		shape:  shape,
+		stride: configStride(shape),
+		eng:    t.eng,
+	}
+}
+
+// arg returns the index of the first element of the Tensor that
+// no other element should replace according to better, as a scalar.
+func (t Tensor[T]) arg(better func(x, y T) bool) Tensor[int] {
+	if t.Err != nil {
+		if t.env().Interactive {
+			panic(t.Err)
+		} else {
+			return Tensor[int]{
+				Err: t.Err,
+			}
+		}
+	}
+
+	return Tensor[int]{
+		data: []int{handleArg(t, better, t.env().configCPU(t.Numel()))},
+		eng:  t.eng,
+	}
+}
+
+// ArgMin returns the index of the minimum value of all elements
+// in the Tensor, as if it were flattened. The first occurrence of
+// the minimum is returned, and NaNs are considered minimal.
+func (t Tensor[T]) ArgMin() Tensor[int] {
+	return t.arg(minFirst[T])
+}
+
+// ArgMax returns the index of the maximum value of all elements
+// in the Tensor, as if it were flattened. The first occurrence of
+// the maximum is returned, and NaNs are considered maximal.
+func (t Tensor[T]) ArgMax() Tensor[int] {
+	return t.arg(maxFirst[T])
+}
+
+// ArgMinAxis returns the indices of the minimum values
+// of the elements along the given axis of the Tensor.
+func (t Tensor[T]) ArgMinAxis(axis int, keepDims bool) Tensor[int] {
+	return t.argAxis("ArgMinAxis", axis, keepDims, minFirst[T])
+}
+
+// ArgMaxAxis returns the indices of the maximum values
+// of the elements along the given axis of the Tensor.
+func (t Tensor[T]) ArgMaxAxis(axis int, keepDims bool) Tensor[int] {
+	return t.argAxis("ArgMaxAxis", axis, keepDims, maxFirst[T])
+}
+
+// sortRun is the length of the runs that are insertion sorted
+// before being merged when sorting indices.
+const sortRun = 16
+
+// before returns whether or not the element of the lane at index a is
+// ordered before the one at index b, in ascending or descending order,
+// where NaNs are ordered last, and equal elements by their indices,
+// which makes the order total and the sorts stable.
+func before[T Number](lane []T, a, b int, descending bool) bool {
+	x, y := lane[a], lane[b]
+	if x == y {
+		return a < b
+	}
+
+	switch {
+	case isNaN(x):
+		return isNaN(y) && a < b
+	case isNaN(y):
+		return true
+	case descending:
+		return x > y
+	default:
+		return x < y
+	}
+}
+
+// sortIndices sorts the indices of the lane's elements according
+// to before, with a bottom-up merge sort using the buffer.
+func sortIndices[T Number](lane []T, idx, buf []int, descending bool) {
+	n := len(idx)
+
+	for lo := 0; lo < n; lo += sortRun {
+		hi := lo + sortRun
+		if hi > n {
+			hi = n
+		}
+
+		for i := lo + 1; i < hi; i++ {
+			x, j := idx[i], i
+			for ; j > lo && before(lane, x, idx[j-1], descending); j-- {
+				idx[j] = idx[j-1]
+			}
+			idx[j] = x
+		}
+	}
+
+	src, dst := idx, buf[:n]
+	for width := sortRun; width < n; width *= 2 {
+		for lo := 0; lo < n; lo += 2 * width {
+			mid, hi := lo+width, lo+2*width
+			if mid > n {
+				mid = n
+			}
+			if hi > n {
+				hi = n
+			}
+
+			i, j, k := lo, mid, lo
+			for ; i < mid && j < hi; k++ {
+				if before(lane, src[j], src[i], descending) {
+					dst[k] = src[j]
+					j++
+				} else {
+					dst[k] = src[i]
+					i++
+				}
+			}
+			k += copy(dst[k:], src[i:mid])
+			copy(dst[k:], src[j:hi])
+		}
+
+		src, dst = dst, src
+	}
+
+	if n > 0 && &src[0] != &idx[0] {
+		copy(idx, src)
+	}
+}
+
+// siftDown restores the heap property of a heap of indices
+// of the lane's elements, whose root is the last one.
+func siftDown[T Number](lane []T, heap []int, i int, descending bool) {
+	for {
+		last, l, r := i, 2*i+1, 2*i+2
+		if l < len(heap) && before(lane, heap[last], heap[l], descending) {
+			last = l
+		}
+		if r < len(heap) && before(lane, heap[last], heap[r], descending) {
+			last = r
+		}
+		if last == i {
+			return
+		}
+
+		heap[i], heap[last] = heap[last], heap[i]
+		i = last
+	}
+}
+
+// argSortLane stably sorts the indices of a lane's elements, in ascending
+// or descending order, and stores the first k of them in idx, which must
+// be able to hold all the lane's indices, as must the buffer.
+// When k is small, the first k indices are selected through a heap.
+func argSortLane[T Number](lane []T, idx, buf []int, k int, descending bool) {
+	if 8*k >= len(lane) {
+		for i := range idx {
+			idx[i] = i
+		}
+		sortIndices(lane, idx, buf, descending)
+
+		return
+	}
+
+	heap := idx[:k]
+	for i := range heap {
+		heap[i] = i
+	}
+	for i := k/2 - 1; i >= 0; i-- {
+		siftDown(lane, heap, i, descending)
+	}
+
+	for i := k; i < len(lane); i++ {
+		if before(lane, i, heap[0], descending) {
+			heap[0] = i
+			siftDown(lane, heap, 0, descending)
+		}
+	}
+
+	sortIndices(lane, heap, buf, descending)
+}
+
+// sortAxis sorts the lanes along the given axis of the Tensor, and stores
+// the first k sorted elements of each lane and their indices along the axis.
+func (t Tensor[T]) sortAxis(op string, axis, k int, descending bool) (Tensor[T], Tensor[int]) {
+	if t.Err != nil {
+		if t.env().Interactive {
+			panic(t.Err)
+		} else {
+			return t, Tensor[int]{
+				Err: t.Err,
+			}
+		}
+	}
+
+	if t, ok := t.verifyAxis(op, axis); !ok {
+		return t, Tensor[int]{
+			Err: t.Err,
+		}
+	}
+
+	if k < 1 || k > t.shape[axis] {
+		err := newOpError(op, ErrBadShape, axis, t.shape)
+		if t.env().Interactive {
+			panic(err)
+		} else {
+			t.Err = err
+			return t, Tensor[int]{
+				Err: err,
+			}
+		}
+	}
+
+	shape := slices.Clone(t.shape)
+	shape[axis] = k
+
+	numel := t.Numel() / t.shape[axis] * k
+	values := slices.WithLen[T](numel)
+	indices := slices.WithLen[int](numel)
+	inner := slices.Prod(t.shape[axis+1:])
+
+	handleLanes(t, axis, func(j int, lane []T) {
+		idx := slices.WithLen[int](len(lane))
+		argSortLane(lane, idx, slices.WithLen[int](len(lane)), k, descending)
+
+		// the lane's position in the results
+		pos := j/inner*k*inner + j%inner
+		for _, i := range idx[:k] {
+			values[pos], indices[pos] = lane[i], i
+			pos += inner
+		}
+	}, t.env().configCPU(t.Numel()))
+
+	return Tensor[T]{
+		data:   values,
+		shape:  shape,
+		stride: configStride(shape),
+		eng:    t.eng,
+	}, Tensor[int]{
+		data:   indices,
+		shape:  slices.Clone(shape),
+		stride: configStride(shape),
+		eng:    t.eng,
+	}
+}
+
+// Sort returns a copy of the Tensor whose elements are sorted along
+// the given axis, in ascending or descending order. The sort is stable,
+// and NaNs are sorted after all other values in both orders.
+func (t Tensor[T]) Sort(axis int, descending bool) Tensor[T] {
+	values, _ := t.sortAxis("Sort", axis, t.sizeOr(axis), descending)
+	return values
+}
+
+// ArgSort returns the indices along the given axis
+// that would sort the Tensor along that axis,
+// with the same ordering as Sort.
+func (t Tensor[T]) ArgSort(axis int, descending bool) Tensor[int] {
+	_, indices := t.sortAxis("ArgSort", axis, t.sizeOr(axis), descending)
+	return indices
+}
+
+// TopK returns the k largest elements along the given axis
+// of the Tensor, in descending order, along with their
+// indices along that axis.
+func (t Tensor[T]) TopK(k, axis int) (values Tensor[T], indices Tensor[int]) {
+	return t.sortAxis("TopK", axis, k, true)
+}
+
+// sizeOr returns the dimensions of the given axis of the Tensor,
+// or 1 if the axis is out of bounds, so that the error
+// is reported by the operation itself.
+func (t Tensor[T]) sizeOr(axis int) int {
+	if axis < 0 || axis >= len(t.shape) {
+		return 1
+	}
+
+	return t.shape[axis]
+}
+
+// Unique returns a rank 1 Tensor holding the sorted unique elements
+// of the Tensor, along with the number of times each of them occurs.
+// All NaNs are considered equal and are returned last.
+func (t Tensor[T]) Unique() (values Tensor[T], counts Tensor[int]) {
+	if t.Err != nil {
+		if t.env().Interactive {
+			panic(t.Err)
+		} else {
+			return t, Tensor[int]{
+				Err: t.Err,
+			}
+		}
+	}
+
+	buf := slices.WithLen[T](t.Numel())
+	copyView(buf, t)
+
+	idx := slices.WithLen[int](len(buf))
+	argSortLane(buf, idx, slices.WithLen[int](len(buf)), len(buf), false)
+
+	uniq := slices.WithCap[T](len(buf))
+	cnts := slices.WithCap[int](len(buf))
+	for i, j := range idx {
+		x := buf[j]
+		if i > 0 && (x == uniq[len(uniq)-1] || isNaN(x) && isNaN(uniq[len(uniq)-1])) {
+			cnts[len(cnts)-1]++
+		} else {
+			uniq = append(uniq, x)
+			cnts = append(cnts, 1)
+		}
+	}
+
+	return Tensor[T]{
+		data:   uniq,
+		shape:  []int{len(uniq)},
+		stride: []int{1},
+		eng:    t.eng,
+	}, Tensor[int]{
+		data:   cnts,
+		shape:  []int{len(cnts)},
+		stride: []int{1},
+		eng:    t.eng,
+	}
+}
@@ -0,0 +1,41 @@
+// Copyright © The Nune Author. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package nune_test
+
+import (
+	"testing"
+)
+
+func BenchmarkArgMax(b *testing.B) {
+	tensor := newTensor()
+
+	benchmarkOp(b, func() {
+		tensor.ArgMax()
+	})
+}
+
+func BenchmarkArgMaxAxis(b *testing.B) {
+	tensor := newTensor().Reshape(1e4, 1e3)
+
+	benchmarkOp(b, func() {
+		tensor.ArgMaxAxis(1, false)
+	})
+}
+
+func BenchmarkSort(b *testing.B) {
+	tensor := newTensor().Reshape(1e4, 1e3)
+
+	benchmarkOp(b, func() {
+		tensor.Sort(1, true)
+	})
+}
+
+func BenchmarkTopK(b *testing.B) {
+	tensor := newTensor().Reshape(1e4, 1e3)
+
+	benchmarkOp(b, func() {
+		tensor.TopK(10, 1)
+	})
+}
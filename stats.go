@@ -0,0 +1,847 @@
+// Copyright © The Nune Author. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package nune
+
+import (
+	"math"
+
+	"github.com/vorduin/slices"
+)
+
+// moments holds the count, the mean and the sum of
+// squared deviations from the mean of a set of values.
+type moments struct {
+	n, mean, m2 float64
+}
+
+// welford returns the moments of a slice,
+// computed with Welford's online algorithm.
+func welford[T Number](s []T) moments {
+	var m moments
+	for _, x := range s {
+		m.n++
+		d := float64(x) - m.mean
+		m.mean += d / m.n
+		m.m2 += d * (float64(x) - m.mean)
+	}
+
+	return m
+}
+
+// merge returns the moments of the union of two sets of values.
+func (m moments) merge(o moments) moments {
+	if m.n == 0 {
+		return o
+	} else if o.n == 0 {
+		return m
+	}
+
+	n := m.n + o.n
+	d := o.mean - m.mean
+
+	return moments{
+		n:    n,
+		mean: m.mean + d*o.n/n,
+		m2:   m.m2 + o.m2 + d*d*m.n*o.n/n,
+	}
+}
+
+// verifyFloat returns the Tensor holding the corresponding error if
+// it doesn't hold floating point elements, for the operations whose
+// results would otherwise be truncated to integers, along with
+// whether or not it does.
+func (t Tensor[T]) verifyFloat(op string, axis int) (Tensor[T], bool) {
+	if !isFloat[T]() {
+		err := newOpError(op, ErrNotFloat, axis, t.shape)
+		if t.env().Interactive {
+			panic(err)
+		} else {
+			t.Err = err
+			return t, false
+		}
+	}
+
+	return t, true
+}
+
+// verifyDdof returns the Tensor holding the corresponding error if the
+// given delta degrees of freedom leave no degree of freedom over n values,
+// along with whether or not they're valid.
+func (t Tensor[T]) verifyDdof(op string, ddof, n, axis int) (Tensor[T], bool) {
+	if ddof < 0 || ddof >= n {
+		err := newOpError(op, ErrBadInterval, axis, t.shape)
+		if t.env().Interactive {
+			panic(err)
+		} else {
+			t.Err = err
+			return t, false
+		}
+	}
+
+	return t, true
+}
+
+// variance returns the variance of all elements in the Tensor,
+// with the given delta degrees of freedom.
+func (t Tensor[T]) variance(op string, ddof int, f func(float64) float64) Tensor[T] {
+	if t.Err != nil {
+		if t.env().Interactive {
+			panic(t.Err)
+		} else {
+			return t
+		}
+	}
+
+	if t, ok := t.verifyFloat(op, -1); !ok {
+		return t
+	}
+
+	if t, ok := t.verifyDdof(op, ddof, t.Numel(), -1); !ok {
+		return t
+	}
+
+	n := t.env().configShares(t.Numel())
+	shares := make([]moments, n)
+
+	handleShares(t, func(i int, s []T) {
+		shares[i] = welford(s)
+	}, n)
+
+	var m moments
+	for _, share := range shares {
+		m = m.merge(share)
+	}
+
+	return Tensor[T]{
+		data: []T{T(f(m.m2 / (m.n - float64(ddof))))},
+		eng:  t.eng,
+	}
+}
+
+// varianceAxis returns the variance of the elements along the given
+// axis of the Tensor, with the given delta degrees of freedom.
+func (t Tensor[T]) varianceAxis(op string, axis, ddof int, keepDims bool, f func(float64) float64) Tensor[T] {
+	if t.Err != nil {
+		if t.env().Interactive {
+			panic(t.Err)
+		} else {
+			return t
+		}
+	}
+
+	if t, ok := t.verifyFloat(op, axis); !ok {
+		return t
+	}
+
+	if t.Rank() > axis && axis >= 0 {
+		if t, ok := t.verifyDdof(op, ddof, t.shape[axis], axis); !ok {
+			return t
+		}
+	}
+
+	return t.ReduceAxis(axis, keepDims, func(s []T) T {
+		m := welford(s)
+		return T(f(m.m2 / (m.n - float64(ddof))))
+	})
+}
+
+// identity returns its argument.
+func identity(x float64) float64 {
+	return x
+}
+
+// Var returns the variance of all elements in the Tensor, computed with
+// Welford's algorithm, where ddof is the delta degrees of freedom,
+// that is the divisor used is the number of elements minus ddof.
+// Since the variance would be truncated to an integer, a Tensor of
+// integers results in ErrNotFloat, as it does for Std, VarAxis and StdAxis.
+func (t Tensor[T]) Var(ddof int) Tensor[T] {
+	return t.variance("Var", ddof, identity)
+}
+
+// Std returns the standard deviation of all elements in the Tensor,
+// with the given delta degrees of freedom.
+func (t Tensor[T]) Std(ddof int) Tensor[T] {
+	return t.variance("Std", ddof, math.Sqrt)
+}
+
+// VarAxis returns the variance of the elements along the
+// given axis of the Tensor, with the given delta degrees of freedom.
+func (t Tensor[T]) VarAxis(axis, ddof int, keepDims bool) Tensor[T] {
+	return t.varianceAxis("VarAxis", axis, ddof, keepDims, identity)
+}
+
+// StdAxis returns the standard deviation of the elements along the
+// given axis of the Tensor, with the given delta degrees of freedom.
+func (t Tensor[T]) StdAxis(axis, ddof int, keepDims bool) Tensor[T] {
+	return t.varianceAxis("StdAxis", axis, ddof, keepDims, math.Sqrt)
+}
+
+// A QuantileMethod is a way of estimating a quantile
+// that falls between two elements.
+type QuantileMethod int
+
+// The methods of estimating a quantile at the position h between
+// the sorted elements i and j, where i <= h <= j.
+const (
+	QuantileLinear   QuantileMethod = iota // the linear interpolation of i and j
+	QuantileLower                          // the element i
+	QuantileHigher                         // the element j
+	QuantileNearest                        // the element nearest to h, or the even one if h is halfway
+	QuantileMidpoint                       // the midpoint of i and j
+)
+
+// selectKth partially sorts the slice so that its k-th element is the
+// element it would hold if it were sorted, with the elements before it
+// being less than or equal to it, and the elements after it greater
+// than or equal to it. The slice must not hold any NaN.
+func selectKth[T Number](s []T, k int) {
+	lo, hi := 0, len(s)-1
+
+	for lo < hi {
+		// the pivot is the median of three elements,
+		// which are sorted in place
+		mid := lo + (hi-lo)/2
+		if s[mid] < s[lo] {
+			s[mid], s[lo] = s[lo], s[mid]
+		}
+		if s[hi] < s[lo] {
+			s[hi], s[lo] = s[lo], s[hi]
+		}
+		if s[hi] < s[mid] {
+			s[hi], s[mid] = s[mid], s[hi]
+		}
+		p := s[mid]
+
+		i, j := lo, hi
+		for i <= j {
+			for s[i] < p {
+				i++
+			}
+			for s[j] > p {
+				j--
+			}
+			if i <= j {
+				s[i], s[j] = s[j], s[i]
+				i++
+				j--
+			}
+		}
+
+		switch {
+		case k <= j:
+			hi = j
+		case k >= i:
+			lo = i
+		default:
+			return
+		}
+	}
+}
+
+// quantile returns the q-th quantile of a slice, which it reorders,
+// or a NaN if the slice holds any.
+func quantile[T Number](s []T, q float64, method QuantileMethod) T {
+	for _, x := range s {
+		if isNaN(x) {
+			return x
+		}
+	}
+
+	h := q * float64(len(s)-1)
+	i, j := int(math.Floor(h)), int(math.Ceil(h))
+
+	selectKth(s, i)
+	lo, hi := float64(s[i]), float64(s[i])
+	if j > i {
+		// the next element is the least of the ones after the i-th
+		hi = float64(reduceMin(s[i+1:]))
+	}
+
+	switch method {
+	case QuantileLower:
+		return T(lo)
+	case QuantileHigher:
+		return T(hi)
+	case QuantileNearest:
+		if int(math.RoundToEven(h)) == i {
+			return T(lo)
+		}
+		return T(hi)
+	case QuantileMidpoint:
+		return T((lo + hi) / 2)
+	default:
+		return T(lo + (h-float64(i))*(hi-lo))
+	}
+}
+
+// verifyQuantile returns the Tensor holding the corresponding error
+// if the given quantile or method is invalid, or if the method
+// interpolates between the elements of a Tensor of integers,
+// along with whether or not they're valid.
+func (t Tensor[T]) verifyQuantile(op string, q float64, method QuantileMethod, axis int) (Tensor[T], bool) {
+	if !(q >= 0 && q <= 1) || method < QuantileLinear || method > QuantileMidpoint {
+		err := newOpError(op, ErrBadInterval, axis, t.shape)
+		if t.env().Interactive {
+			panic(err)
+		} else {
+			t.Err = err
+			return t, false
+		}
+	}
+
+	if method == QuantileLinear || method == QuantileMidpoint {
+		return t.verifyFloat(op, axis)
+	}
+
+	return t, true
+}
+
+// Quantile returns the q-th quantile of all elements in the Tensor,
+// where q is in the interval [0, 1], estimated with the given method
+// when it falls between two elements. The quantile of elements
+// holding a NaN is a NaN. Since interpolated quantiles would be
+// truncated to integers, a Tensor of integers results in ErrNotFloat
+// with QuantileLinear and QuantileMidpoint, and thus with Median.
+func (t Tensor[T]) Quantile(q float64, method QuantileMethod) Tensor[T] {
+	if t.Err != nil {
+		if t.env().Interactive {
+			panic(t.Err)
+		} else {
+			return t
+		}
+	}
+
+	if t, ok := t.verifyQuantile("Quantile", q, method, -1); !ok {
+		return t
+	}
+
+	buf := slices.WithLen[T](t.Numel())
+	copyView(buf, t)
+
+	return Tensor[T]{
+		data: []T{quantile(buf, q, method)},
+		eng:  t.eng,
+	}
+}
+
+// QuantileAxis returns the q-th quantile of the elements along the
+// given axis of the Tensor, estimated with the given method.
+func (t Tensor[T]) QuantileAxis(q float64, axis int, method QuantileMethod, keepDims bool) Tensor[T] {
+	if t.Err != nil {
+		if t.env().Interactive {
+			panic(t.Err)
+		} else {
+			return t
+		}
+	}
+
+	if t, ok := t.verifyQuantile("QuantileAxis", q, method, axis); !ok {
+		return t
+	}
+
+	// the lanes are copies, which can be reordered
+	return t.ReduceAxis(axis, keepDims, func(s []T) T {
+		return quantile(s, q, method)
+	})
+}
+
+// Median returns the median value of all elements in the Tensor,
+// which is the mean of the two middle elements if there are two.
+func (t Tensor[T]) Median() Tensor[T] {
+	return t.Quantile(0.5, QuantileLinear)
+}
+
+// MedianAxis returns the median value of the elements
+// along the given axis of the Tensor.
+func (t Tensor[T]) MedianAxis(axis int, keepDims bool) Tensor[T] {
+	return t.QuantileAxis(0.5, axis, QuantileLinear, keepDims)
+}
+
+// histBounds returns the bounds of a histogram of the Tensor's elements,
+// which are the given bounds, or the minimum and maximum elements if the
+// bounds are both 0. Bounds that are equal are widened by 0.5 on each side.
+func (t Tensor[T]) histBounds(bounds [2]float64) (float64, float64) {
+	lo, hi := bounds[0], bounds[1]
+
+	if lo == 0 && hi == 0 {
+		nCPU := t.env().configCPU(t.Numel())
+		los, his := slices.WithLen[float64](nCPU), slices.WithLen[float64](nCPU)
+
+		handleShares(t, func(i int, s []T) {
+			// NaNs are skipped since they fail both comparisons
+			lo, hi := math.Inf(1), math.Inf(-1)
+			for _, x := range s {
+				v := float64(x)
+				if v < lo {
+					lo = v
+				}
+				if v > hi {
+					hi = v
+				}
+			}
+			los[i], his[i] = lo, hi
+		}, nCPU)
+
+		lo, hi = reduceMin(los), reduceMax(his)
+		if math.IsInf(lo, 1) {
+			lo, hi = 0, 1
+		}
+	}
+
+	if lo == hi {
+		lo, hi = lo-0.5, hi+0.5
+	}
+
+	return lo, hi
+}
+
+// verifyHistogram returns the Tensor holding the corresponding error
+// if the given number of bins or bounds are invalid, along with
+// whether or not they're valid.
+func (t Tensor[T]) verifyHistogram(op string, bins int, bounds [2]float64, axis int) (Tensor[T], bool) {
+	var err error
+	if bins < 1 {
+		err = ErrBadShape
+	} else if !(bounds[0] <= bounds[1]) || math.IsInf(bounds[0], 0) || math.IsInf(bounds[1], 0) {
+		err = ErrBadInterval
+	}
+
+	if err != nil {
+		err = newOpError(op, err, axis, t.shape)
+		if t.env().Interactive {
+			panic(err)
+		} else {
+			t.Err = err
+			return t, false
+		}
+	}
+
+	return t, true
+}
+
+// histEdges returns the edges of the given number of bins
+// evenly spaced over the interval [lo, hi].
+func histEdges(bins int, lo, hi float64, eng *Engine) Tensor[float64] {
+	edges := slices.WithLen[float64](bins + 1)
+	for i := range edges {
+		edges[i] = lo + (hi-lo)*float64(i)/float64(bins)
+	}
+	edges[bins] = hi
+
+	return Tensor[float64]{
+		data:   edges,
+		shape:  []int{bins + 1},
+		stride: []int{1},
+		eng:    eng,
+	}
+}
+
+// histogram counts the elements of a slice falling in each of the bins
+// evenly spaced over the interval [lo, hi], where the last bin includes
+// its upper edge, and stores the counts at the given positions.
+func histogram[T Number](s []T, counts []int, pos, step, bins int, lo, hi float64) {
+	scale := float64(bins) / (hi - lo)
+
+	for _, x := range s {
+		v := float64(x)
+		if !(v >= lo && v <= hi) {
+			continue
+		}
+
+		b := int((v - lo) * scale)
+		if b >= bins {
+			b = bins - 1
+		}
+		counts[pos+b*step]++
+	}
+}
+
+// Histogram returns the number of the Tensor's elements falling in each
+// of the given number of bins, evenly spaced over the given bounds, along
+// with the edges of the bins. The last bin includes its upper edge, and
+// the elements out of the bounds, as well as NaNs, are ignored.
+// If the bounds are both 0, the minimum and maximum elements are used.
+func (t Tensor[T]) Histogram(bins int, bounds [2]float64) (counts Tensor[int], edges Tensor[float64]) {
+	if t.Err != nil {
+		if t.env().Interactive {
+			panic(t.Err)
+		} else {
+			return Tensor[int]{Err: t.Err}, Tensor[float64]{Err: t.Err}
+		}
+	}
+
+	if t, ok := t.verifyHistogram("Histogram", bins, bounds, -1); !ok {
+		return Tensor[int]{Err: t.Err}, Tensor[float64]{Err: t.Err}
+	}
+
+	lo, hi := t.histBounds(bounds)
+
+	nCPU := t.env().configCPU(t.Numel())
+	shares := slices.WithLen[int](nCPU * bins)
+
+	handleShares(t, func(i int, s []T) {
+		histogram(s, shares[i*bins:(i+1)*bins], 0, 1, bins, lo, hi)
+	}, nCPU)
+
+	out := shares[:bins]
+	for i := 1; i < nCPU; i++ {
+		for b, c := range shares[i*bins : (i+1)*bins] {
+			out[b] += c
+		}
+	}
+
+	return Tensor[int]{
+		data:   out,
+		shape:  []int{bins},
+		stride: []int{1},
+		eng:    t.eng,
+	}, histEdges(bins, lo, hi, t.eng)
+}
+
+// HistogramAxis returns the histograms of the lanes along the given axis
+// of the Tensor, whose counts replace the axis, along with the edges of
+// the bins, which are shared by all the lanes.
+func (t Tensor[T]) HistogramAxis(axis, bins int, bounds [2]float64) (counts Tensor[int], edges Tensor[float64]) {
+	if t.Err != nil {
+		if t.env().Interactive {
+			panic(t.Err)
+		} else {
+			return Tensor[int]{Err: t.Err}, Tensor[float64]{Err: t.Err}
+		}
+	}
+
+	if t, ok := t.verifyAxis("HistogramAxis", axis); !ok {
+		return Tensor[int]{Err: t.Err}, Tensor[float64]{Err: t.Err}
+	}
+
+	if t, ok := t.verifyHistogram("HistogramAxis", bins, bounds, axis); !ok {
+		return Tensor[int]{Err: t.Err}, Tensor[float64]{Err: t.Err}
+	}
+
+	lo, hi := t.histBounds(bounds)
+
+	shape := slices.Clone(t.shape)
+	shape[axis] = bins
+
+	out := slices.WithLen[int](slices.Prod(shape))
+	inner := slices.Prod(t.shape[axis+1:])
+
+	handleLanes(t, axis, func(j int, lane []T) {
+		histogram(lane, out, j/inner*bins*inner+j%inner, inner, bins, lo, hi)
+	}, t.env().configCPU(t.Numel()))
+
+	return Tensor[int]{
+		data:   out,
+		shape:  shape,
+		stride: configStride(shape),
+		eng:    t.eng,
+	}, histEdges(bins, lo, hi, t.eng)
+}
+
+// bincountLength returns the number of bins needed to count the Tensor's
+// elements, which must all be non-negative, or -1 if they aren't.
+func (t Tensor[T]) bincountLength(minLength int) int {
+	nCPU := t.env().configCPU(t.Numel())
+	maxs := slices.WithLen[int](nCPU)
+
+	handleShares(t, func(i int, s []T) {
+		for _, x := range s {
+			if !(x >= 0) {
+				maxs[i] = -1
+				return
+			}
+			if int(x) > maxs[i] {
+				maxs[i] = int(x)
+			}
+		}
+	}, nCPU)
+
+	for _, m := range maxs {
+		if m < 0 {
+			return -1
+		}
+	}
+
+	n := reduceMax(maxs) + 1
+	if n < minLength {
+		n = minLength
+	}
+
+	return n
+}
+
+// verifyBincount returns the Tensor holding the corresponding error
+// if the number of bins is invalid, along with whether or not it is.
+func (t Tensor[T]) verifyBincount(op string, n, axis int) (Tensor[T], bool) {
+	if n < 0 {
+		err := newOpError(op, ErrBadInterval, axis, t.shape)
+		if t.env().Interactive {
+			panic(err)
+		} else {
+			t.Err = err
+			return t, false
+		}
+	}
+
+	return t, true
+}
+
+// Bincount returns the number of occurrences of each integer in the
+// Tensor's elements, which must all be non-negative, and are truncated
+// to integers. The result has a bin for each integer up to the maximum
+// element, and at least minLength bins.
+func (t Tensor[T]) Bincount(minLength int) Tensor[int] {
+	if t.Err != nil {
+		if t.env().Interactive {
+			panic(t.Err)
+		} else {
+			return Tensor[int]{Err: t.Err}
+		}
+	}
+
+	n := t.bincountLength(minLength)
+	if t, ok := t.verifyBincount("Bincount", n, -1); !ok {
+		return Tensor[int]{Err: t.Err}
+	}
+
+	nCPU := t.env().configCPU(t.Numel())
+	shares := slices.WithLen[int](nCPU * n)
+
+	handleShares(t, func(i int, s []T) {
+		counts := shares[i*n : (i+1)*n]
+		for _, x := range s {
+			counts[int(x)]++
+		}
+	}, nCPU)
+
+	out := shares[:n]
+	for i := 1; i < nCPU; i++ {
+		for b, c := range shares[i*n : (i+1)*n] {
+			out[b] += c
+		}
+	}
+
+	return Tensor[int]{
+		data:   out,
+		shape:  []int{n},
+		stride: []int{1},
+		eng:    t.eng,
+	}
+}
+
+// BincountAxis returns the number of occurrences of each integer in the
+// lanes along the given axis of the Tensor, whose counts replace the axis.
+// All the lanes have the same number of bins.
+func (t Tensor[T]) BincountAxis(axis, minLength int) Tensor[int] {
+	if t.Err != nil {
+		if t.env().Interactive {
+			panic(t.Err)
+		} else {
+			return Tensor[int]{Err: t.Err}
+		}
+	}
+
+	if t, ok := t.verifyAxis("BincountAxis", axis); !ok {
+		return Tensor[int]{Err: t.Err}
+	}
+
+	n := t.bincountLength(minLength)
+	if t, ok := t.verifyBincount("BincountAxis", n, axis); !ok {
+		return Tensor[int]{Err: t.Err}
+	}
+
+	shape := slices.Clone(t.shape)
+	shape[axis] = n
+
+	out := slices.WithLen[int](slices.Prod(shape))
+	inner := slices.Prod(t.shape[axis+1:])
+
+	handleLanes(t, axis, func(j int, lane []T) {
+		pos := j/inner*n*inner + j%inner
+		for _, x := range lane {
+			out[pos+int(x)*inner]++
+		}
+	}, t.env().configCPU(t.Numel()))
+
+	return Tensor[int]{
+		data:   out,
+		shape:  shape,
+		stride: configStride(shape),
+		eng:    t.eng,
+	}
+}
+
+// covariance returns the covariance matrix of the variables of a rank 1
+// or 2 Tensor, whose observations are along the given axis, as float64s.
+// The observations are centered, then the products of each pair of
+// variables are computed concurrently for each share of the variables.
+func (t Tensor[T]) covariance(axis, ddof int) ([]float64, int) {
+	if t.Rank() == 1 {
+		t = t.Unsqueeze(0)
+		axis = 1
+	} else if axis == 0 {
+		t = t.Permute(1, 0)
+	}
+
+	nv, n := t.shape[0], t.shape[1]
+
+	x := slices.WithLen[float64](nv * n)
+	for i := 0; i < nv; i++ {
+		row := x[i*n : (i+1)*n]
+
+		var mean float64
+		for j := range row {
+			row[j] = float64(t.data[t.offset+i*t.stride[0]+j*t.stride[1]])
+			mean += row[j]
+		}
+		mean /= float64(n)
+
+		for j := range row {
+			row[j] -= mean
+		}
+	}
+
+	cov := slices.WithLen[float64](nv * nv)
+	div := float64(n - ddof)
+
+	parallelize(nv, t.env().configCPU(nv*nv*n/2), func(_, min, max int) {
+		for i := min; i < max; i++ {
+			a := x[i*n : (i+1)*n]
+			for j := i; j < nv; j++ {
+				b := x[j*n : (j+1)*n]
+
+				var dot float64
+				for k := range a {
+					dot += a[k] * b[k]
+				}
+
+				cov[i*nv+j] = dot / div
+				cov[j*nv+i] = dot / div
+			}
+		}
+	})
+
+	return cov, nv
+}
+
+// verifyCov returns the Tensor holding the corresponding error if the
+// Tensor doesn't hold floating point elements, if it isn't rank 1 or 2,
+// or if the axis or the delta degrees of freedom are invalid, along
+// with whether or not they're valid.
+func (t Tensor[T]) verifyCov(op string, axis, ddof int) (Tensor[T], bool) {
+	t, ok := t.verifyFloat(op, axis)
+	if !ok {
+		return t, false
+	}
+
+	if t.Rank() < 1 || t.Rank() > 2 {
+		err := newOpError(op, ErrBadShape, axis, t.shape)
+		if t.env().Interactive {
+			panic(err)
+		} else {
+			t.Err = err
+			return t, false
+		}
+	}
+
+	t, ok = t.verifyAxis(op, axis)
+	if !ok {
+		return t, false
+	}
+
+	return t.verifyDdof(op, ddof, t.shape[axis], axis)
+}
+
+// fromCov returns a Tensor holding the covariance matrix of nv variables,
+// or a scalar if there is a single variable in a rank 1 Tensor.
+func (t Tensor[T]) fromCov(cov []float64, nv int) Tensor[T] {
+	data := slices.WithLen[T](len(cov))
+	for i, c := range cov {
+		data[i] = T(c)
+	}
+
+	if t.Rank() == 1 {
+		return Tensor[T]{
+			data: data,
+			eng:  t.eng,
+		}
+	}
+
+	return Tensor[T]{
+		data:   data,
+		shape:  []int{nv, nv},
+		stride: []int{nv, 1},
+		eng:    t.eng,
+	}
+}
+
+// Cov returns the covariance matrix of the variables held in the rows
+// of a rank 2 Tensor, whose columns are observations, or the variance
+// of a rank 1 Tensor, with the given delta degrees of freedom.
+func (t Tensor[T]) Cov(ddof int) Tensor[T] {
+	return t.CovAxis(t.Rank()-1, ddof)
+}
+
+// CovAxis returns the covariance matrix of the variables of a rank 1 or 2
+// Tensor, whose observations are along the given axis, with the given
+// delta degrees of freedom. Since the covariances would be truncated
+// to integers, a Tensor of integers results in ErrNotFloat, and must
+// be cast to a floating point type first.
+func (t Tensor[T]) CovAxis(axis, ddof int) Tensor[T] {
+	if t.Err != nil {
+		if t.env().Interactive {
+			panic(t.Err)
+		} else {
+			return t
+		}
+	}
+
+	if t, ok := t.verifyCov("CovAxis", axis, ddof); !ok {
+		return t
+	}
+
+	return t.fromCov(t.covariance(axis, ddof))
+}
+
+// Corrcoef returns the Pearson correlation coefficients of the variables
+// held in the rows of a rank 2 Tensor, whose columns are observations.
+func (t Tensor[T]) Corrcoef() Tensor[T] {
+	return t.CorrcoefAxis(t.Rank() - 1)
+}
+
+// CorrcoefAxis returns the Pearson correlation coefficients of the
+// variables of a rank 1 or 2 Tensor, whose observations are along the
+// given axis. The coefficients of variables with no variance are NaNs.
+// As with CovAxis, a Tensor of integers results in ErrNotFloat.
+func (t Tensor[T]) CorrcoefAxis(axis int) Tensor[T] {
+	if t.Err != nil {
+		if t.env().Interactive {
+			panic(t.Err)
+		} else {
+			return t
+		}
+	}
+
+	if t, ok := t.verifyCov("CorrcoefAxis", axis, 0); !ok {
+		return t
+	}
+
+	cov, nv := t.covariance(axis, 0)
+
+	std := slices.WithLen[float64](nv)
+	for i := range std {
+		std[i] = math.Sqrt(cov[i*nv+i])
+	}
+
+	for i := 0; i < nv; i++ {
+		for j := 0; j < nv; j++ {
+			// rounding errors might push the coefficients out of [-1, 1]
+			cov[i*nv+j] = math.Max(-1, math.Min(1, cov[i*nv+j]/(std[i]*std[j])))
+		}
+	}
+
+	return t.fromCov(cov, nv)
+}
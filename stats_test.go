@@ -0,0 +1,87 @@
+// Copyright © The Nune Author. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package nune_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/vorduin/nune"
+)
+
+func BenchmarkVar(b *testing.B) {
+	tensor := newTensor()
+
+	benchmarkOp(b, func() {
+		tensor.Var(0)
+	})
+}
+
+func BenchmarkVarAxis(b *testing.B) {
+	tensor := newTensor().Reshape(1e4, 1e3)
+
+	benchmarkOp(b, func() {
+		tensor.VarAxis(0, 0, false)
+	})
+}
+
+func BenchmarkMedian(b *testing.B) {
+	tensor := newTensor()
+
+	benchmarkOp(b, func() {
+		tensor.Median()
+	})
+}
+
+func BenchmarkHistogram(b *testing.B) {
+	tensor := newTensor()
+
+	benchmarkOp(b, func() {
+		tensor.Histogram(100, [2]float64{})
+	})
+}
+
+func TestCorrcoefInts(t *testing.T) {
+	ints := nune.Range[int](0, 6, 1).Reshape(2, 3)
+
+	if c := ints.Corrcoef(); !errors.Is(c.Err, nune.ErrNotFloat) {
+		t.Errorf("Corrcoef: got error %v, want %v", c.Err, nune.ErrNotFloat)
+	}
+	if c := ints.Cov(1); !errors.Is(c.Err, nune.ErrNotFloat) {
+		t.Errorf("Cov: got error %v, want %v", c.Err, nune.ErrNotFloat)
+	}
+
+	c := nune.Cast[float64](ints).Corrcoef()
+	if c.Err != nil {
+		t.Fatal(c.Err)
+	}
+	for _, x := range c.Ravel() {
+		if x != 1 {
+			t.Fatalf("Corrcoef: got %v, want ones", c.Ravel())
+		}
+	}
+}
+
+func TestStatsInts(t *testing.T) {
+	ints := nune.Range[int](0, 6, 1).Reshape(2, 3)
+
+	for name, out := range map[string]nune.Tensor[int]{
+		"Var":      ints.Var(0),
+		"Std":      ints.Std(0),
+		"VarAxis":  ints.VarAxis(1, 0, false),
+		"StdAxis":  ints.StdAxis(1, 0, false),
+		"Median":   ints.Median(),
+		"Quantile": ints.QuantileAxis(0.3, 1, nune.QuantileMidpoint, false),
+	} {
+		if !errors.Is(out.Err, nune.ErrNotFloat) {
+			t.Errorf("%s: got error %v, want %v", name, out.Err, nune.ErrNotFloat)
+		}
+	}
+
+	// the methods picking an element have integer results
+	if q := ints.Quantile(0.5, nune.QuantileLower); q.Err != nil || q.Scalar() != 2 {
+		t.Errorf("Quantile: got %v, want 2", q)
+	}
+}
@@ -0,0 +1,191 @@
+// Copyright © The Nune Author. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package nune
+
+import (
+	"github.com/vorduin/slices"
+)
+
+// isContiguous returns whether or not the given layout
+// describes a compact, row-major view over a data buffer.
+func isContiguous(shape, stride []int) bool {
+	expected := 1
+	for i := len(shape) - 1; i >= 0; i-- {
+		if shape[i] != 1 && stride[i] != expected {
+			return false
+		}
+		expected *= shape[i]
+	}
+
+	return true
+}
+
+// coalesce simplifies the layouts of views sharing the same shape
+// by dropping the axes of dimensions 1 and by merging the adjacent
+// axes that are contiguous in all of the views, so that they can
+// be walked over in longer runs.
+func coalesce(shape []int, strides ...[]int) ([]int, [][]int) {
+	newshape := slices.WithCap[int](len(shape))
+	newstrides := make([][]int, len(strides))
+	for i := range newstrides {
+		newstrides[i] = slices.WithCap[int](len(shape))
+	}
+
+	for axis := 0; axis < len(shape); axis++ {
+		if shape[axis] == 1 {
+			continue
+		}
+
+		last := len(newshape) - 1
+
+		merge := last >= 0
+		for i := 0; i < len(strides) && merge; i++ {
+			merge = newstrides[i][last] == shape[axis]*strides[i][axis]
+		}
+
+		if merge {
+			newshape[last] *= shape[axis]
+			for i := range strides {
+				newstrides[i][last] = strides[i][axis]
+			}
+		} else {
+			newshape = append(newshape, shape[axis])
+			for i := range strides {
+				newstrides[i] = append(newstrides[i], strides[i][axis])
+			}
+		}
+	}
+
+	return newshape, newstrides
+}
+
+// A walker walks over the positions of a strided view in its data buffer,
+// in row-major order, one run along the view's last axis at a time.
+type walker struct {
+	shape, stride []int // the walked view's layout
+	index         []int // the current multi-index in the view
+	pos           int   // the current position in the data buffer
+}
+
+// newWalker returns a walker over the given view,
+// placed at the element with the given row-major index.
+func newWalker(shape, stride []int, offset, start int) *walker {
+	w := &walker{
+		shape:  shape,
+		stride: stride,
+		index:  slices.WithLen[int](len(shape)),
+		pos:    offset,
+	}
+
+	for i := len(shape) - 1; i >= 0; i-- {
+		w.index[i] = start % shape[i]
+		w.pos += w.index[i] * stride[i]
+		start /= shape[i]
+	}
+
+	return w
+}
+
+// run returns the position and step size of the current run of
+// at most n elements along the view's last axis, along with
+// the run's length, and moves the walker past that run.
+func (w *walker) run(n int) (pos, step, l int) {
+	if len(w.shape) == 0 {
+		return w.pos, 0, 1
+	}
+
+	last := len(w.shape) - 1
+
+	pos, step = w.pos, w.stride[last]
+	l = w.shape[last] - w.index[last]
+	if l > n {
+		l = n
+	}
+
+	w.index[last] += l
+	w.pos += l * step
+
+	for i := last; i > 0 && w.index[i] == w.shape[i]; i-- {
+		w.pos += w.stride[i-1] - w.shape[i]*w.stride[i]
+		w.index[i] = 0
+		w.index[i-1]++
+	}
+
+	return pos, step, l
+}
+
+// copyView copies the elements of a view, in row-major
+// order, into the given buffer, casting them along the way.
+func copyView[T Number, U Number](dst []T, src Tensor[U]) {
+	shape, strides := coalesce(src.shape, src.stride)
+
+	w := newWalker(shape, strides[0], src.offset, 0)
+	for i := 0; i < len(dst); {
+		pos, step, l := w.run(len(dst) - i)
+		for j := 0; j < l; j++ {
+			dst[i] = T(src.data[pos])
+			pos += step
+			i++
+		}
+	}
+}
+
+// IsContiguous returns whether or not the Tensor's view
+// is compact and laid out in row-major order in its data buffer,
+// in which case Ravel returns exactly the Tensor's elements.
+func (t Tensor[T]) IsContiguous() bool {
+	return isContiguous(t.shape, t.stride)
+}
+
+// Contiguous returns the Tensor if its view is contiguous,
+// and otherwise returns a compact copy of the Tensor's view.
+func (t Tensor[T]) Contiguous() Tensor[T] {
+	if t.Err != nil {
+		if t.env().Interactive {
+			panic(t.Err)
+		} else {
+			return t
+		}
+	}
+
+	if t.IsContiguous() {
+		return t
+	}
+
+	return t.Clone()
+}
+
+// handleViews concurrently walks over views sharing the same shape in
+// lockstep, calling f on each run of elements with the row-major index
+// of the run's first element, the positions of that element in each
+// view's data buffer, the views' step sizes, and the run's length.
+func handleViews(shape []int, offsets []int, strides [][]int, nCPU int, f func(i int, pos, step []int, l int)) {
+	numel := 1
+	for _, d := range shape {
+		numel *= d
+	}
+
+	shape, strides = coalesce(shape, strides...)
+
+	parallelize(numel, nCPU, func(_, min, max int) {
+		walkers := make([]*walker, len(strides))
+		for k := range walkers {
+			walkers[k] = newWalker(shape, strides[k], offsets[k], min)
+		}
+
+		pos := slices.WithLen[int](len(walkers))
+		step := slices.WithLen[int](len(walkers))
+
+		for i := min; i < max; {
+			var l int
+			for k, w := range walkers {
+				pos[k], step[k], l = w.run(max - i)
+			}
+
+			f(i, pos, step, l)
+			i += l
+		}
+	})
+}
@@ -0,0 +1,27 @@
+// Copyright © The Nune Author. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package nune_test
+
+import (
+	"testing"
+
+	"github.com/vorduin/nune"
+)
+
+func BenchmarkContiguous1e6(b *testing.B) {
+	tensor := nune.Range[float64](0, 1e6, 1).Reshape(10, 200, 500).Permute(2, 1, 0)
+
+	benchmarkMicro(b, func() {
+		tensor.Contiguous()
+	})
+}
+
+func BenchmarkAddPermuted(b *testing.B) {
+	tensor := newTensor().Reshape(1e4, 1e3).Permute(1, 0)
+
+	benchmarkOp(b, func() {
+		tensor.Add(tensor)
+	})
+}
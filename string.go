@@ -14,7 +14,7 @@ import (
 // String returns a string representation of the Tensor.
 func (t Tensor[T]) String() string {
 	if t.Err != nil {
-		if EnvConfig.Interactive {
+		if t.env().Interactive {
 			panic(t.Err)
 		} else {
 			return "Tensor(error)"
@@ -38,7 +38,7 @@ func fmtTensor[T Number](t Tensor[T], s fmtState) string {
 	} else {
 		b.WriteString("[")
 
-		if t.Size(0) > FmtConfig.Excerpt {
+		if t.Size(0) > s.opts.Excerpt {
 			b.WriteString(fmtExcerpted(t, s))
 		} else {
 			b.WriteString(fmtComplete(t, s))
@@ -54,9 +54,9 @@ func fmtTensor[T Number](t Tensor[T], s fmtState) string {
 func fmtNum[T Number](x T, s fmtState) string {
 	switch reflect.ValueOf(x).Kind() {
 	case reflect.Float32, reflect.Float64:
-		return fmt.Sprintf("%*.*f", s.width, FmtConfig.Precision, float64(x))
+		return fmt.Sprintf("%*.*f", s.width, s.opts.Precision, float64(x))
 	case reflect.Uint8:
-		if FmtConfig.Btoa {
+		if s.opts.Btoa {
 			return fmt.Sprintf("%s", string(byte(x)))
 		}
 		fallthrough
@@ -72,7 +72,7 @@ func fmtExcerpted[T Number](t Tensor[T], s fmtState) string {
 
 	var f string
 
-	f = fmtTensor(t.Slice(0, FmtConfig.Excerpt/2), s)
+	f = fmtTensor(t.Slice(0, s.opts.Excerpt/2), s)
 	f = f[1 : len(f)-1]
 	b.WriteString(f)
 
@@ -85,7 +85,7 @@ func fmtExcerpted[T Number](t Tensor[T], s fmtState) string {
 		b.WriteString(strings.Repeat(" ", s.pad+1))
 	}
 
-	f = fmtTensor(t.Slice(t.Size(0)-FmtConfig.Excerpt/2, t.Size(0)), s)
+	f = fmtTensor(t.Slice(t.Size(0)-s.opts.Excerpt/2, t.Size(0)), s)
 	f = f[1 : len(f)-1]
 	b.WriteString(f)
 
@@ -120,6 +120,7 @@ func fmtComplete[T Number](t Tensor[T], s fmtState) string {
 // A fmtState holds the format configurations while formatting a Tensor.
 type fmtState struct {
 	depth, esc, pad, width int
+	opts                   FmtOptions
 }
 
 // update prepares all the fmtState configurations for the next format call.
@@ -137,6 +138,7 @@ func newFmtState[T Number](fmt string, t Tensor[T]) fmtState {
 	s := fmtState{
 		depth: 0,
 		esc:   t.Rank() - 1,
+		opts:  t.env().Fmt,
 	}
 
 	s.pad = cfgPad(fmt)
@@ -159,11 +161,13 @@ func cfgWidth[T Number](t Tensor[T]) int {
 	x := T(math.Max(math.Abs(float64(min)), math.Abs(float64(max))))
 	var l int
 
+	opts := t.env().Fmt
+
 	switch reflect.ValueOf(x).Kind() {
 	case reflect.Float32, reflect.Float64:
-		l = len(fmt.Sprintf("%.*f", FmtConfig.Precision, float64(x)))
+		l = len(fmt.Sprintf("%.*f", opts.Precision, float64(x)))
 	case reflect.Uint8:
-		if FmtConfig.Btoa {
+		if opts.Btoa {
 			l = 1
 		}
 		fallthrough
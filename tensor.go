@@ -13,8 +13,9 @@ type Number interface {
 
 // A Tensor is a generic, n-dimensional numerical type.
 type Tensor[T Number] struct {
-	data          []T   // the tensor's data buffer
-	shape, stride []int // the layout that holds the Tensor's indexing scheme
-	offset        int   // the Tensor's view offset in the data buffer
-	Err           error // holds the corresponding error when a Tensor operation fails
+	data          []T     // the tensor's data buffer
+	shape, stride []int   // the layout that holds the Tensor's indexing scheme
+	offset        int     // the Tensor's view offset in the data buffer
+	eng           *Engine // the Engine the Tensor is bound to, if any
+	Err           error   // holds the corresponding error when a Tensor operation fails
 }
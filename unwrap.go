@@ -39,7 +39,7 @@ func unwrapAny[T Number](s []any, shape []int) ([]T, []int, error) {
 			}
 		}
 
-		shape = append(shape, len(s)*d)
+		shape = append(shape, d)
 
 		return unwrapAny[T](p, shape)
 	}
@@ -7,6 +7,7 @@ package nune
 import (
 	"math"
 	"runtime"
+
 	"github.com/vorduin/slices"
 )
 
@@ -26,11 +27,11 @@ func configStride(shape []int) []int {
 	return nil
 }
 
-// configCPU returns the number of CPU cores to use 
-// depending on the data's size.
-func configCPU(size int) int {
-	if EnvConfig.NumCPU != 0 {
-		return int(math.Min(float64(size), float64(EnvConfig.NumCPU)))
+// configCPU returns the number of CPU cores to use
+// depending on the data's size and the Engine's configuration.
+func (e Engine) configCPU(size int) int {
+	if e.NumCPU != 0 {
+		return int(math.Min(float64(size), float64(e.NumCPU)))
 	}
 
 	bias := float64(size) / 4096 // this is handcoded, therefore beautiful. or ugly
@@ -42,4 +43,24 @@ func configCPU(size int) int {
 	} else {
 		return runtime.NumCPU()
 	}
-}
\ No newline at end of file
+}
+
+// reduceBlock is the number of elements of the shares
+// of a reduction in a deterministic environment.
+const reduceBlock = 1 << 16
+
+// configShares returns the number of shares to split a reduction over
+// the given number of elements into. It's the number of CPU cores to use,
+// unless the Engine is deterministic, in which case it only depends on
+// the number of elements, so that the shares are combined the same way
+// regardless of the number of CPU cores.
+func (e Engine) configShares(size int) int {
+	if e.Deterministic {
+		if size <= reduceBlock {
+			return 1
+		}
+		return (size + reduceBlock - 1) / reduceBlock
+	}
+
+	return e.configCPU(size)
+}
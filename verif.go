@@ -4,7 +4,11 @@
 
 package nune
 
-import "errors"
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
 
 // List of errors.
 var (
@@ -38,11 +42,98 @@ var (
 	// (0, rank) bounds.
 	ErrAxisBounds = errors.New("nune: axis out of bounds")
 
+	// ErrShapeMismatch occurs when the shapes of two Tensors
+	// do not match as required by an operation.
+	ErrShapeMismatch = errors.New("nune: tensors' shapes do not match")
+
+	// ErrNotSqueezable occurs when squeezing an axis
+	// whose dimensions are greater than 1.
+	ErrNotSqueezable = errors.New("nune: could not squeeze axis of dimensions greater than 1")
+
+	// ErrBadNpy occurs when data read as a NumPy .npy
+	// file does not follow the file format.
+	ErrBadNpy = errors.New("nune: received bad npy data")
+
 	// ErrStorageDump occurs when the Assign method fails to dump
 	// the given data to the Tensor's storage.
 	ErrStorageDump = errors.New("nune: could not dump data buffer to storage")
+
+	// ErrBadIndex occurs when an index given to the S method is neither
+	// an int, a Span nor a Marker, or when it holds more than one Ellipsis.
+	ErrBadIndex = errors.New("nune: received a bad index")
+
+	// ErrReadOnly occurs when writing in place to a broadcast view,
+	// such as the one returned by Expand, whose broadcast axes
+	// walk over the same elements for all their indices.
+	ErrReadOnly = errors.New("nune: could not write to a broadcast view")
+
+	// ErrNotFloat occurs when an operation whose results aren't
+	// integers, such as Var or Cov, is performed on a Tensor of integers.
+	ErrNotFloat = errors.New("nune: operation requires floating point elements")
+
+	// ErrBadMode occurs when an operation receives a mode
+	// it doesn't define, such as an unknown PadMode.
+	ErrBadMode = errors.New("nune: received a bad mode")
 )
 
+// An OpError records a failed Tensor operation, along with the
+// shapes of its operands and the axis it operated along, if any.
+// Since a failed Tensor carries its error through all subsequent
+// operations, the OpError describes the first failure in a chain.
+type OpError struct {
+	Op     string  // the name of the failed operation
+	Shapes [][]int // the shapes of the operation's operands
+	Axis   int     // the axis the operation operated along, or -1
+	Err    error   // the underlying error
+}
+
+// Error returns a description of the failed operation.
+func (e *OpError) Error() string {
+	var b strings.Builder
+
+	b.WriteString("nune: ")
+	b.WriteString(e.Op)
+
+	for _, s := range e.Shapes {
+		fmt.Fprintf(&b, " %v", s)
+	}
+
+	if e.Axis >= 0 {
+		fmt.Fprintf(&b, " axis %d", e.Axis)
+	}
+
+	b.WriteString(": ")
+	b.WriteString(strings.TrimPrefix(e.Err.Error(), "nune: "))
+
+	return b.String()
+}
+
+// Unwrap returns the underlying error.
+func (e *OpError) Unwrap() error {
+	return e.Err
+}
+
+// newOpError returns an OpError wrapping the given error, unless the
+// error already records an earlier failed operation.
+func newOpError(op string, err error, axis int, shapes ...[]int) error {
+	var e *OpError
+	if errors.As(err, &e) {
+		return err
+	}
+
+	s := make([][]int, len(shapes))
+	for i, shape := range shapes {
+		s[i] = append([]int{}, shape...)
+	}
+
+	return &OpError{
+		Op:     op,
+		Shapes: s,
+		Axis:   axis,
+		Err:    err,
+	}
+}
+
 // verifyGoodShape makes sure a shape isn't empty,
 // and that none of the shapes axes's dimensions
 // are less than or equal to zero, and panics otherwise.
@@ -110,3 +201,15 @@ func verifyAxisBounds(axis, rank int) error {
 	}
 	return nil
 }
+
+// verifyWritable makes sure a view doesn't walk over an axis
+// of dimensions greater than 1 with a stride of 0, whose
+// indices would all be written to the same elements.
+func verifyWritable(shape, stride []int) error {
+	for i, s := range stride {
+		if s == 0 && shape[i] > 1 {
+			return ErrReadOnly
+		}
+	}
+	return nil
+}
@@ -5,72 +5,103 @@
 package nune
 
 import (
-	"sync"
 	"github.com/vorduin/slices"
 )
 
-// handleZip processes an elementwise operation accordingly.
-func handleZip[T Number](lhs, rhs, out []T, f func(T, T) T, nCPU int) {
-	var wg sync.WaitGroup
-
-	for i := 0; i < nCPU; i++ {
-		min := (i * len(lhs) / nCPU)
-		max := ((i + 1) * len(lhs)) / nCPU
-
-		wg.Add(1)
-		go func(lhsBuf, rhsBuf, outBuf []T) {
-			for j := 0; j < len(lhsBuf); j++ {
-				outBuf[j] = f(lhsBuf[j], rhsBuf[j])
+// handleZip processes an elementwise operation accordingly,
+// walking over both Tensors' views in their data buffers
+// and storing the results in the left-hand side Tensor.
+// The contiguous runs are processed by the operation's dedicated
+// kernel, if any, and by calling f otherwise.
+func handleZip[T Number](lhs, rhs Tensor[T], op kernelOp, f func(T, T) T, nCPU int) {
+	shape, strides := coalesce(lhs.shape, lhs.stride, rhs.stride)
+
+	parallelize(lhs.Numel(), nCPU, func(_, min, max int) {
+		lw := newWalker(shape, strides[0], lhs.offset, min)
+		rw := newWalker(shape, strides[1], rhs.offset, min)
+
+		for n := max - min; n > 0; {
+			lpos, lstep, l := lw.run(n)
+			rpos, rstep, _ := rw.run(n)
+
+			switch {
+			case lstep == 1 && rstep == 1:
+				lhsBuf := lhs.data[lpos : lpos+l]
+				rhsBuf := rhs.data[rpos : rpos+l]
+				if !zipKernel(op, lhsBuf, rhsBuf) {
+					for j := 0; j < len(lhsBuf); j++ {
+						lhsBuf[j] = f(lhsBuf[j], rhsBuf[j])
+					}
+				}
+			case lstep == 1 && rstep == 0 && zipScalarKernel(op, lhs.data[lpos:lpos+l], rhs.data[rpos]):
+				// the run was processed by the kernel
+			default:
+				for j := 0; j < l; j++ {
+					lhs.data[lpos] = f(lhs.data[lpos], rhs.data[rpos])
+					lpos += lstep
+					rpos += rstep
+				}
 			}
 
-			wg.Done()
-		}(lhs[min:max], rhs[min:max], out[min:max])
-	}
-
-	wg.Wait()
+			n -= l
+		}
+	})
 }
 
-// midwayBroadcast adjusts the first shape so that the second shape might
-// be broadcastable to it. For example, for the shape [4, 1] to be
-// broadcastable with the shape [3], this function returns that
-// the broadcasting shape should be [4, 3].
-func midwayBroadcast(s1, s2 []int) []int {
-	var s []int
-
+// broadcastShapes returns the shape both given shapes can be broadcast
+// to, aligning them by their trailing axes. For example, the shapes
+// [4, 1] and [3] are broadcast together to the shape [4, 3].
+func broadcastShapes(s1, s2 []int) ([]int, error) {
 	if len(s1) < len(s2) {
-		s = slices.WithLen[int](len(s2))
-		for i := 0; i < len(s2)-len(s1); i++ {
-			s[i] = 1
-		}
-		copy(s[len(s2)-len(s1):], s1)
-	} else {
-		s = s1
+		s1, s2 = s2, s1
 	}
 
+	s := slices.Clone(s1)
+	lead := len(s1) - len(s2)
+
 	for i := 0; i < len(s2); i++ {
-		if s[i] != s2[i] && s[i] == 1 {
-			s[i] = s2[i]
+		switch d := s[lead+i]; {
+		case d == s2[i] || s2[i] == 1:
+		case d == 1:
+			s[lead+i] = s2[i]
+		default:
+			return nil, ErrNotBroadable
 		}
 	}
 
-	return s
+	return s, nil
 }
 
 // Zip performs an elementwise operation
 // between other and this Tensor.
 func (t Tensor[T]) Zip(other any, f func(T, T) T) Tensor[T] {
+	return t.zip(other, opNone, f)
+}
+
+// zip performs an elementwise operation between other and this Tensor,
+// using the operation's dedicated kernel where possible.
+func (t Tensor[T]) zip(other any, op kernelOp, f func(T, T) T) Tensor[T] {
 	if t.Err != nil {
-		if EnvConfig.Interactive {
+		if t.env().Interactive {
 			panic(t.Err)
 		} else {
 			return t
 		}
 	}
 
-	o := From[T](other)
+	o, ok := other.(Tensor[T])
+	if !ok {
+		o = From[T](other)
+	} else if o.Err == nil && len(o.data) > 0 && &o.data[0] == &t.data[0] &&
+		(o.offset != t.offset || !slices.Equal(o.shape, t.shape) || !slices.Equal(o.stride, t.stride)) {
+		// the other Tensor overlaps this one, so it must
+		// not be read after this Tensor is written to
+		o = o.Clone()
+	}
+
 	if o.Err != nil {
-		if EnvConfig.Interactive {
-			panic(t.Err)
+		if t.env().Interactive {
+			panic(o.Err)
 		} else {
 			t.Err = o.Err
 			return t
@@ -78,26 +109,33 @@ func (t Tensor[T]) Zip(other any, f func(T, T) T) Tensor[T] {
 	}
 
 	if !slices.Equal(t.shape, o.shape) {
-		if s := midwayBroadcast(o.shape, t.shape); t.Broadable(s...) && !slices.Equal(s, t.shape) {
-			t = t.Broadcast(s...)
-		}
-
-		if s := midwayBroadcast(t.Shape(), o.Shape()); o.Broadable(s...) && !slices.Equal(s, o.shape) {
-			o = o.Broadcast(s...)
-		}
-
-		if !slices.Equal(t.shape, o.shape) {
-			if EnvConfig.Interactive {
-				panic(ErrNotBroadable)
+		s, err := broadcastShapes(t.shape, o.shape)
+		if err != nil {
+			err = newOpError("Zip", err, -1, t.shape, o.shape)
+			if t.env().Interactive {
+				panic(err)
 			} else {
-				t.Err = ErrNotBroadable
+				t.Err = err
 				return t
 			}
 		}
+
+		// this Tensor holds the results, so it needs its own buffer
+		// when broadcast, while the other Tensor only needs a view
+		if !slices.Equal(s, t.shape) {
+			t = t.Broadcast(s...)
+		}
+
+		o = o.Expand(s...)
+	}
+
+	// the shared elements of a broadcast view would be written to
+	// several times, so the results can't be stored in such a view
+	if t, ok := t.writable("Zip"); !ok {
+		return t
 	}
 
-	// TODO: Fix if the Tensor was permutated.
-	handleZip(t.Ravel(), o.Ravel(), t.Ravel(), f, configCPU(t.Numel()))
+	handleZip(t, o, op, f, t.env().configCPU(t.Numel()))
 
 	return t
 }
@@ -105,7 +143,7 @@ func (t Tensor[T]) Zip(other any, f func(T, T) T) Tensor[T] {
 // Add takes a value and performs elementwise addition
 // between other and this Tensor.
 func (t Tensor[T]) Add(other any) Tensor[T] {
-	return t.Zip(other, func(x, y T) T {
+	return t.zip(other, opAdd, func(x, y T) T {
 		return x + y
 	})
 }
@@ -113,7 +151,7 @@ func (t Tensor[T]) Add(other any) Tensor[T] {
 // Sub takes a value and performs elementwise subtraction
 // between other and this Tensor.
 func (t Tensor[T]) Sub(other any) Tensor[T] {
-	return t.Zip(other, func(x, y T) T {
+	return t.zip(other, opSub, func(x, y T) T {
 		return x - y
 	})
 }
@@ -121,7 +159,7 @@ func (t Tensor[T]) Sub(other any) Tensor[T] {
 // Mul takes a value and performs elementwise multiplication
 // between other and this Tensor.
 func (t Tensor[T]) Mul(other any) Tensor[T] {
-	return t.Zip(other, func(x, y T) T {
+	return t.zip(other, opMul, func(x, y T) T {
 		return x * y
 	})
 }
@@ -129,7 +167,7 @@ func (t Tensor[T]) Mul(other any) Tensor[T] {
 // Div takes a value and performs elementwise division
 // between other and this Tensor.
 func (t Tensor[T]) Div(other any) Tensor[T] {
-	return t.Zip(other, func(x, y T) T {
+	return t.zip(other, opDiv, func(x, y T) T {
 		return x / y
 	})
 }
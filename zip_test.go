@@ -6,6 +6,8 @@ package nune_test
 
 import (
 	"testing"
+
+	"github.com/vorduin/nune"
 )
 
 func BenchmarkAdd(b *testing.B) {
@@ -37,4 +39,13 @@ func BenchmarkDiv(b *testing.B) {
 	benchmarkOp(b, func() {
 		tensor.Div(tensor)
 	})
-}
\ No newline at end of file
+}
+
+func BenchmarkAddBroadcast(b *testing.B) {
+	tensor := newTensor().Reshape(1e4, 1e3)
+	bias := nune.Range[float64](0, 1e3, 1)
+
+	benchmarkOp(b, func() {
+		tensor.Add(bias)
+	})
+}